@@ -114,6 +114,55 @@ func (bp BezierPath) Points() []F {
 	return cp
 }
 
+// Split divides the curve at t using De Casteljau's algorithm. The first curve
+// goes from t=0 to t and the second from t to t=1, and each is the same degree
+// as bp. A value of t outside [0,1] extends one of the curves.
+func (bp BezierPath) Split(t float64) (BezierPath, BezierPath) {
+	n := len(bp.ps)
+	if n == 0 {
+		return BezierPath{}, BezierPath{}
+	}
+	pts := bp.Points()
+	left := make([]F, n)
+	right := make([]F, n)
+	left[0], right[n-1] = pts[0], pts[n-1]
+	for k := 1; k < n; k++ {
+		for i := 0; i < n-k; i++ {
+			pts[i] = pts[i].Lerp(pts[i+1], t)
+		}
+		left[k], right[n-1-k] = pts[0], pts[n-1-k]
+	}
+	return NewBezierPath(left...), NewBezierPath(right...)
+}
+
+// Sub returns the part of the curve from t0 to t1 as a curve of the same
+// degree, so that Sub(t0, t1).F(0) is F(t0) and Sub(t0, t1).F(1) is F(t1). If
+// t0 is greater than t1, the curve is reversed.
+func (bp BezierPath) Sub(t0, t1 float64) BezierPath {
+	// Control point i is found by De Casteljau's algorithm using t0 for the
+	// first n-1-i steps and t1 for the rest.
+	n := len(bp.ps)
+	if n == 0 {
+		return BezierPath{}
+	}
+	out := make([]F, n)
+	pts := make([]F, n)
+	for i := range out {
+		copy(pts, bp.ps)
+		for k := 1; k < n; k++ {
+			t := t1
+			if k < n-i {
+				t = t0
+			}
+			for j := 0; j < n-k; j++ {
+				pts[j] = pts[j].Lerp(pts[j+1], t)
+			}
+		}
+		out[i] = pts[0]
+	}
+	return NewBezierPath(out...)
+}
+
 // CompositeBezierSegment is a segment of a composite bezier curve where each
 // segment is defined by 4 points. The individual segments are 3 points because
 // the 4th point is taken from the previous segment. The first value is the
@@ -126,7 +175,6 @@ type CompositeBezierSegment [3]F
 // point they are connected to. The 3rd point is the next control point defined
 // relative to the previous control point.
 func NewRelativeCompositeBezier(segments []CompositeBezierSegment, transformation Transformation) CompositeBezier {
-	curves := make([]Curve, len(segments))
 	points := make([][]F, len(segments))
 	var prev F
 	for i, seg := range segments {
@@ -137,7 +185,14 @@ func NewRelativeCompositeBezier(segments []CompositeBezierSegment, transformatio
 		pts[2] = seg[1].Add(pts[3])
 		prev = pts[3]
 		points[i] = transformation.Slice(pts)
-		curves[i] = NewBezierCurve(points[i]...)
+	}
+	return newCompositeBezier(points)
+}
+
+func newCompositeBezier(points [][]F) CompositeBezier {
+	curves := make([]Curve, len(points))
+	for i, pts := range points {
+		curves[i] = NewBezierCurve(pts...)
 	}
 	return CompositeBezier{
 		CompositeCurve: curves,
@@ -145,12 +200,21 @@ func NewRelativeCompositeBezier(segments []CompositeBezierSegment, transformatio
 	}
 }
 
-// CompositeBezier with each segment being a quadratic Bezier curve.
+// CompositeBezier with each segment being a cubic Bezier curve.
 type CompositeBezier struct {
 	CompositeCurve
 	points [][]F
 }
 
+// Points returns a copy of the control points of each segment.
+func (cb CompositeBezier) Points() [][]F {
+	cp := make([][]F, len(cb.points))
+	for i, pts := range cb.points {
+		cp[i] = append([]F(nil), pts...)
+	}
+	return cp
+}
+
 // CompositeCurve stitches multiple curves together into a single parametric
 // curve.
 type CompositeCurve []Curve
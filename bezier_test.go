@@ -53,3 +53,42 @@ func TestNewRelativeCompositeBezier(t *testing.T) {
 	assert.Equal(t, F{1, 2.25}, c.F(1.25))
 
 }
+
+func TestBezierPathSplit(t *testing.T) {
+	bp := NewBezierPath(F{0, 0}, F{1, 2}, F{2, 0})
+	l, r := bp.Split(0.5)
+	assert.Equal(t, []F{{0, 0}, {0.5, 1}, {1, 1}}, l.Points())
+	assert.Equal(t, []F{{1, 1}, {1.5, 1}, {2, 0}}, r.Points())
+
+	bp = NewBezierPath(F{0, 0}, F{0, 5}, F{3, 6}, F{5, 0}, F{7, 2})
+	for _, st := range []float64{0, 0.3, 0.75, 1, 1.5} {
+		l, r = bp.Split(st)
+		assert.Len(t, l.Points(), 5)
+		for i := 0; i <= 10; i++ {
+			u := float64(i) / 10
+			assert.True(t, bp.F(st*u).Near(l.F(u), 1e-9))
+			assert.True(t, bp.F(st+(1-st)*u).Near(r.F(u), 1e-9))
+		}
+	}
+
+	l, r = BezierPath{}.Split(0.5)
+	assert.Len(t, l.Points(), 0)
+	assert.Len(t, r.Points(), 0)
+}
+
+func TestBezierPathSub(t *testing.T) {
+	bp := NewBezierPath(F{0, 0}, F{0, 5}, F{3, 6}, F{5, 0})
+	assert.Equal(t, bp.Points(), bp.Sub(0, 1).Points())
+	_, r := bp.Split(0.25)
+	assert.Equal(t, r.Points(), bp.Sub(0.25, 1).Points())
+
+	for _, ts := range [][2]float64{{0.2, 0.7}, {0.7, 0.2}, {0, 0.4}, {0.5, 0.5}, {-0.5, 1.5}} {
+		sub := bp.Sub(ts[0], ts[1])
+		assert.True(t, bp.F(ts[0]).Near(sub.F(0), 1e-9))
+		assert.True(t, bp.F(ts[1]).Near(sub.F(1), 1e-9))
+		for i := 0; i <= 10; i++ {
+			u := float64(i) / 10
+			assert.True(t, bp.F(ts[0]+(ts[1]-ts[0])*u).Near(sub.F(u), 1e-9))
+		}
+	}
+}
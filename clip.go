@@ -0,0 +1,141 @@
+package vec2d
+
+import (
+	"math"
+)
+
+// ClipRect clips the segment of l between t=0 and t=1 to the axis aligned
+// rectangle with corners p1 and p2, the same rectangle as RectangleToPoints.
+// It returns the range of t on l that lies inside the rectangle, including the
+// edges of the rectangle. If no part of the segment is inside the rectangle, ok
+// is false. This uses the Liang–Barsky algorithm.
+func (l Line) ClipRect(p1, p2 F) (t0, t1 float64, ok bool) {
+	return clipRect(l(0), l(1), p1, p2)
+}
+
+// ClipRect returns the part of the segment inside the axis aligned rectangle
+// with corners p1 and p2. If no part of the segment is inside the rectangle,
+// ok is false. See Line.ClipRect.
+func (s Segment) ClipRect(p1, p2 F) (clipped Segment, ok bool) {
+	t0, t1, ok := clipRect(s[0], s[1], p1, p2)
+	if !ok {
+		return Segment{}, false
+	}
+	return Segment{pointAt(s[0], s[1], t0), pointAt(s[0], s[1], t1)}, true
+}
+
+// ClipRect returns the parts of ls that are inside the axis aligned rectangle
+// with corners p1 and p2. Each time ls leaves the rectangle and comes back a
+// new LineSegments is started. See Line.ClipRect.
+func (ls LineSegments) ClipRect(p1, p2 F) []LineSegments {
+	var out []LineSegments
+	var cur LineSegments
+	if len(ls) == 1 {
+		if _, _, ok := clipRect(ls[0], ls[0], p1, p2); ok {
+			out = append(out, LineSegments{ls[0]})
+		}
+		return out
+	}
+	for i := 1; i < len(ls); i++ {
+		a, b := ls[i-1], ls[i]
+		t0, t1, ok := clipRect(a, b, p1, p2)
+		if !ok {
+			continue
+		}
+		if cur == nil || t0 > 0 {
+			if cur != nil {
+				out = append(out, cur)
+			}
+			cur = LineSegments{pointAt(a, b, t0)}
+		}
+		cur = append(cur, pointAt(a, b, t1))
+		if t1 < 1 {
+			out = append(out, cur)
+			cur = nil
+		}
+	}
+	if cur != nil {
+		out = append(out, cur)
+	}
+	return out
+}
+
+func clipRect(a, b, p1, p2 F) (t0, t1 float64, ok bool) {
+	lo := F{math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y)}
+	hi := F{math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y)}
+	d := b.Subtract(a)
+	ps := [4]float64{-d.X, d.X, -d.Y, d.Y}
+	qs := [4]float64{a.X - lo.X, hi.X - a.X, a.Y - lo.Y, hi.Y - a.Y}
+	t0, t1 = 0, 1
+	for i, p := range ps {
+		q := qs[i]
+		if p == 0 {
+			// parallel to this edge
+			if q < 0 {
+				return 0, 0, false
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return 0, 0, false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return 0, 0, false
+			}
+			t1 = math.Min(t1, r)
+		}
+	}
+	return t0, t1, true
+}
+
+// Clip returns the part of p that is inside the convex polygon. The convex
+// polygon may proceed in either direction, but if it is not convex the result
+// is not correct. If p is concave and the result would be disconnected, the
+// parts are joined by edges along the sides of convex. If no part of p is
+// inside convex, nil is returned. This uses the Sutherland–Hodgman algorithm.
+func (p Polygon) Clip(convex Polygon) Polygon {
+	if len(convex) < 3 || len(p) == 0 {
+		return nil
+	}
+	dir := 1.0
+	if convex.SignedArea() < 0 {
+		dir = -1
+	}
+	out := append(Polygon(nil), p...)
+	prevC := convex[len(convex)-1]
+	for _, c := range convex {
+		in := out
+		out = nil
+		if len(in) == 0 {
+			break
+		}
+		side := func(f F) float64 { return dir * Orient(prevC, c, f) }
+		prev := in[len(in)-1]
+		prevSide := side(prev)
+		for _, f := range in {
+			s := side(f)
+			if (s > 0 && prevSide < 0) || (s < 0 && prevSide > 0) {
+				out = append(out, pointAt(prev, f, prevSide/(prevSide-s)))
+			}
+			if s >= 0 {
+				out = append(out, f)
+			}
+			prev, prevSide = f, s
+		}
+		prevC = c
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// ClipRect returns the part of p inside the axis aligned rectangle with
+// corners p1 and p2. See Clip.
+func (p Polygon) ClipRect(p1, p2 F) Polygon {
+	return p.Clip(RectangleToPoints(p1, p2))
+}
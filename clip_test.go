@@ -0,0 +1,78 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLineClipRect(t *testing.T) {
+	p1, p2 := F{4, 4}, F{0, 0}
+	t0, t1, ok := F{-2, 2}.LineTo(F{6, 2}).ClipRect(p1, p2)
+	assert.True(t, ok)
+	assert.Equal(t, 0.25, t0)
+	assert.Equal(t, 0.75, t1)
+
+	// entirely inside
+	t0, t1, ok = F{1, 1}.LineTo(F{3, 2}).ClipRect(p1, p2)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, t0)
+	assert.Equal(t, 1.0, t1)
+
+	// outside, including a line that would cross the rectangle if extended
+	_, _, ok = F{5, 0}.LineTo(F{6, 4}).ClipRect(p1, p2)
+	assert.False(t, ok)
+	_, _, ok = F{-2, 2}.LineTo(F{-1, 2}).ClipRect(p1, p2)
+	assert.False(t, ok)
+
+	// along an edge
+	t0, t1, ok = F{-4, 4}.LineTo(F{4, 4}).ClipRect(p1, p2)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, t0)
+	assert.Equal(t, 1.0, t1)
+
+	s, ok := Segment{{-2, -2}, {2, 6}}.ClipRect(p1, p2)
+	assert.True(t, ok)
+	assert.Equal(t, Segment{{0, 2}, {1, 4}}, s)
+	_, ok = Segment{{5, 5}, {6, 6}}.ClipRect(p1, p2)
+	assert.False(t, ok)
+}
+
+func TestLineSegmentsClipRect(t *testing.T) {
+	ls := LineSegments{{-1, 1}, {1, 1}, {1, 3}, {5, 3}, {5, 2}, {3, 2}, {3, -1}}
+	got := ls.ClipRect(F{0, 0}, F{4, 4})
+	assert.Equal(t, []LineSegments{
+		{{0, 1}, {1, 1}, {1, 3}, {4, 3}},
+		{{4, 2}, {3, 2}, {3, 0}},
+	}, got)
+
+	assert.Nil(t, ls.ClipRect(F{10, 10}, F{11, 11}))
+	assert.Equal(t, []LineSegments{{{1, 1}}}, LineSegments{{1, 1}}.ClipRect(F{0, 0}, F{4, 4}))
+}
+
+func TestPolygonClip(t *testing.T) {
+	sq := RectangleToPoints(F{0, 0}, F{4, 4})
+
+	// a triangle with one corner cut off
+	tri := Polygon{{2, 2}, {6, 2}, {2, 6}}
+	got := tri.Clip(sq)
+	assert.True(t, got.Equivalent(Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}}, 1e-12), got)
+
+	// the direction of the clip polygon does not matter
+	got = tri.Clip(sq.Reverse())
+	assert.True(t, got.Equivalent(Polygon{{2, 2}, {4, 2}, {4, 4}, {2, 4}}, 1e-12), got)
+
+	// entirely inside and entirely outside
+	inner := RectangleToPoints(F{1, 1}, F{2, 2})
+	assert.Equal(t, inner, inner.Clip(sq))
+	assert.Nil(t, Polygon{{5, 5}, {6, 5}, {6, 6}}.Clip(sq))
+
+	// clipping a larger polygon returns the clip region
+	got = RectangleToPoints(F{-1, -1}, F{5, 5}).ClipRect(F{0, 0}, F{4, 4})
+	assert.True(t, got.Equivalent(sq, 1e-12), got)
+
+	// clip against a triangle
+	tri2 := Polygon{{0, 0}, {4, 0}, {0, 4}}
+	got = RectangleToPoints(F{1, -1}, F{5, 5}).Clip(tri2)
+	assert.True(t, got.Equivalent(Polygon{{1, 0}, {4, 0}, {1, 3}}, 1e-12), got)
+	assert.InDelta(t, 4.5, got.Area(), 1e-12)
+}
@@ -163,6 +163,18 @@ func (e Ellipse) Arc() EllipseArc {
 	return e.perimeter
 }
 
+// Near returns true if the foci of the ellipses are within eps of each other,
+// in either order, and the minor radii are within eps.
+func (e Ellipse) Near(e2 Ellipse, eps float64) bool {
+	if !Near(e.perimeter.sma, e2.perimeter.sma, eps) {
+		return false
+	}
+	a0, a1 := e.perimeter.Foci()
+	b0, b1 := e2.perimeter.Foci()
+	return (a0.Near(b0, eps) && a1.Near(b1, eps)) ||
+		(a0.Near(b1, eps) && a1.Near(b0, eps))
+}
+
 // Circle fulfills Shape.
 type Circle struct {
 	e Ellipse
@@ -209,3 +221,10 @@ func (c Circle) Centroid() F { return c.e.perimeter.c }
 
 // Arc returns the EllipseArc that represent the perimeter of the circle
 func (c Circle) Arc() EllipseArc { return c.e.perimeter }
+
+// Near returns true if the centers and radii of the circles are within eps of
+// each other.
+func (c Circle) Near(c2 Circle, eps float64) bool {
+	return c.e.perimeter.c.Near(c2.e.perimeter.c, eps) &&
+		Near(c.e.perimeter.sma, c2.e.perimeter.sma, eps)
+}
@@ -90,3 +90,15 @@ func TestAssertTypes(t *testing.T) {
 	})
 	_ = s
 }
+
+func TestEllipseNear(t *testing.T) {
+	e := NewEllipse(F{0, 0}, F{2, 0}, 1)
+	assert.True(t, e.Near(NewEllipse(F{2, 0}, F{0, 0}, 1), Epsilon))
+	assert.True(t, e.Near(NewEllipse(F{0, 0}, F{2, 1e-12}, 1), Epsilon))
+	assert.False(t, e.Near(NewEllipse(F{0, 0}, F{2, 0}, 1.5), Epsilon))
+	assert.False(t, e.Near(NewEllipse(F{0, 0}, F{0, 2}, 1), Epsilon))
+
+	c := NewCircle(F{1, 1}, 2)
+	assert.True(t, c.Near(Triangle{{-1, 1}, {3, 1}, {1, 3}}.CircumscribedCircle(), Epsilon))
+	assert.False(t, c.Near(NewCircle(F{1, 1}, 2.1), Epsilon))
+}
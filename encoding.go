@@ -0,0 +1,1045 @@
+package vec2d
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DecodeErr is returned when binary or JSON data cannot be decoded into a
+// geometry type. Text that cannot be decoded returns a ParseErr.
+type DecodeErr string
+
+// Error fulfils the error interface
+func (d DecodeErr) Error() string {
+	return string(d)
+}
+
+const (
+	errShortBinary = DecodeErr("binary data is too short")
+	errLongBinary  = DecodeErr("binary data has trailing bytes")
+	errBadLength   = DecodeErr("binary data has an invalid length prefix")
+	errJSONLength  = DecodeErr("JSON array has the wrong number of values")
+	errEmptyCurve  = DecodeErr("CompositeBezier segment has no control points")
+	errTangents    = DecodeErr("Hermite needs an In and Out tangent for each point")
+)
+
+// unmarshalJSONArray decodes a JSON array into a slice and checks that it has
+// n values. Decoding into a fixed size array would silently drop extra values
+// or fill missing ones with 0.
+func unmarshalJSONArray[T any](data []byte, n int) ([]T, error) {
+	var v []T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if len(v) != n {
+		return nil, errJSONLength
+	}
+	return v, nil
+}
+
+func appendFloatText(b []byte, f float64) []byte {
+	return strconv.AppendFloat(b, f, 'g', -1, 64)
+}
+
+func appendFText(b []byte, f F) []byte {
+	b = append(b, '(')
+	b = appendFloatText(b, f.X)
+	b = append(b, ", "...)
+	b = appendFloatText(b, f.Y)
+	return append(b, ')')
+}
+
+func appendFsText(b []byte, fs []F) []byte {
+	for i, f := range fs {
+		if i > 0 {
+			b = append(b, ':')
+		}
+		b = appendFText(b, f)
+	}
+	return b
+}
+
+func parseFloats(typ, s string, strs []string) ([]float64, error) {
+	out := make([]float64, len(strs))
+	for i, str := range strs {
+		f, err := parseFloat(typ, s, "value", strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+func appendFloatBinary(b []byte, f float64) []byte {
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+}
+
+func appendFBinary(b []byte, f F) []byte {
+	return appendFloatBinary(appendFloatBinary(b, f.X), f.Y)
+}
+
+func appendFsBinary(b []byte, fs []F) []byte {
+	b = binary.AppendUvarint(b, uint64(len(fs)))
+	for _, f := range fs {
+		b = appendFBinary(b, f)
+	}
+	return b
+}
+
+// binaryReader consumes fixed width values from a byte slice, recording the
+// first error.
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) float() float64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) < 8 {
+		r.err = errShortBinary
+		return 0
+	}
+	f := math.Float64frombits(binary.LittleEndian.Uint64(r.data))
+	r.data = r.data[8:]
+	return f
+}
+
+func (r *binaryReader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) < 8 {
+		r.err = errShortBinary
+		return 0
+	}
+	i := int(int64(binary.LittleEndian.Uint64(r.data)))
+	r.data = r.data[8:]
+	return i
+}
+
+func (r *binaryReader) f() F {
+	return F{r.float(), r.float()}
+}
+
+// count reads a uvarint length prefix for a list where each value takes at
+// least size bytes.
+func (r *binaryReader) count(size int) int {
+	if r.err != nil {
+		return 0
+	}
+	ln, n := binary.Uvarint(r.data)
+	if n <= 0 || ln > uint64(len(r.data)-n)/uint64(size) {
+		r.err = errBadLength
+		return 0
+	}
+	r.data = r.data[n:]
+	return int(ln)
+}
+
+func (r *binaryReader) fs() []F {
+	ln := r.count(16)
+	if r.err != nil {
+		return nil
+	}
+	fs := make([]F, ln)
+	for i := range fs {
+		fs[i] = r.f()
+	}
+	return fs
+}
+
+// done returns the first error or an error if there is unread data.
+func (r *binaryReader) done() error {
+	if r.err == nil && len(r.data) > 0 {
+		r.err = errLongBinary
+	}
+	return r.err
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The format matches String, but
+// with enough precision to decode the exact value.
+func (f F) MarshalText() ([]byte, error) {
+	return appendFText(nil, f), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (f *F) UnmarshalText(text []byte) error {
+	v, err := ParseF(string(text))
+	if err == nil {
+		*f = v
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding F as [X, Y].
+func (f F) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{f.X, f.Y})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (f *F) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSONArray[float64](data, 2)
+	if err != nil {
+		return err
+	}
+	*f = F{v[0], v[1]}
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding F as 16 bytes.
+func (f F) MarshalBinary() ([]byte, error) {
+	return appendFBinary(nil, f), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (f *F) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	v := r.f()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The format matches String.
+func (i I) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (i *I) UnmarshalText(text []byte) error {
+	v, err := ParseI(string(text))
+	if err == nil {
+		*i = v
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding I as [X, Y].
+func (i I) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{i.X, i.Y})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (i *I) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSONArray[int](data, 2)
+	if err != nil {
+		return err
+	}
+	*i = I{v[0], v[1]}
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding I as 16 bytes.
+func (i I) MarshalBinary() ([]byte, error) {
+	b := binary.LittleEndian.AppendUint64(nil, uint64(i.X))
+	return binary.LittleEndian.AppendUint64(b, uint64(i.Y)), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (i *I) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	v := I{r.int(), r.int()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The format matches String, but
+// with enough precision to decode the exact value.
+func (p P) MarshalText() ([]byte, error) {
+	b := []byte{'('}
+	b = appendFloatText(b, p.M)
+	b = append(b, ", "...)
+	b = appendFloatText(b, p.A)
+	return append(b, " rad )"...), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (p *P) UnmarshalText(text []byte) error {
+	v, err := ParseP(string(text))
+	if err == nil {
+		*p = v
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding P as [M, A].
+func (p P) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{p.M, p.A})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (p *P) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSONArray[float64](data, 2)
+	if err != nil {
+		return err
+	}
+	*p = P{v[0], v[1]}
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding P as 16 bytes.
+func (p P) MarshalBinary() ([]byte, error) {
+	return appendFBinary(nil, F{p.M, p.A}), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (p *P) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	v := r.f()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*p = P{v.X, v.Y}
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The format matches String, but
+// with enough precision to decode the exact value.
+func (p Polygon) MarshalText() ([]byte, error) {
+	return appendFsText(nil, p), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (p *Polygon) UnmarshalText(text []byte) error {
+	fs, err := ParsePolygon(string(text))
+	if err == nil {
+		*p = fs
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the Polygon as a list of
+// [X, Y] pairs.
+func (p Polygon) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]F(p))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (p *Polygon) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*[]F)(p))
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler. The number of vertexes is
+// written as a uvarint followed by 16 bytes per vertex.
+func (p Polygon) MarshalBinary() ([]byte, error) {
+	return appendFsBinary(nil, p), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (p *Polygon) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	fs := r.fs()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*p = fs
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler, using the same format as
+// Polygon.
+func (ls LineSegments) MarshalText() ([]byte, error) {
+	return appendFsText(nil, ls), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (ls *LineSegments) UnmarshalText(text []byte) error {
+	fs, err := parseFs("LineSegments", string(text))
+	if err == nil {
+		*ls = fs
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the LineSegments as a list of
+// [X, Y] pairs.
+func (ls LineSegments) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]F(ls))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (ls *LineSegments) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*[]F)(ls))
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, using the same format as
+// Polygon.
+func (ls LineSegments) MarshalBinary() ([]byte, error) {
+	return appendFsBinary(nil, ls), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (ls *LineSegments) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	fs := r.fs()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*ls = fs
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler, using the same format as
+// Polygon.
+func (t Triangle) MarshalText() ([]byte, error) {
+	return appendFsText(nil, t[:]), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (t *Triangle) UnmarshalText(text []byte) error {
+	fs, err := parseFs("Triangle", string(text))
+	if err != nil {
+		return err
+	}
+	if len(fs) != 3 {
+		return newParseErr("Triangle", string(text), "expected 3 vertexes")
+	}
+	copy(t[:], fs)
+	return nil
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the Triangle as a list of 3
+// [X, Y] pairs.
+func (t Triangle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]F(t))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (t *Triangle) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalJSONArray[F](data, 3)
+	if err != nil {
+		return err
+	}
+	copy(t[:], v)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the Triangle as 48
+// bytes.
+func (t Triangle) MarshalBinary() ([]byte, error) {
+	return appendFBinary(appendFBinary(appendFBinary(nil, t[0]), t[1]), t[2]), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (t *Triangle) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	v := Triangle{r.f(), r.f(), r.f()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The Translation, X and Y vectors
+// are written in that order, separated by colons.
+func (t Transformation) MarshalText() ([]byte, error) {
+	return appendFsText(nil, []F{t.Translation, t.X, t.Y}), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (t *Transformation) UnmarshalText(text []byte) error {
+	fs, err := parseFs("Transformation", string(text))
+	if err != nil {
+		return err
+	}
+	if len(fs) != 3 {
+		return newParseErr("Transformation", string(text), "expected 3 vectors")
+	}
+	*t = Transformation{
+		Translation: fs[0],
+		X:           fs[1],
+		Y:           fs[2],
+	}
+	return nil
+}
+
+// transformationJSON has the same fields as Transformation without the
+// methods so it can be encoded by reflection.
+type transformationJSON struct {
+	Translation F
+	X           F
+	Y           F
+}
+
+// MarshalJSON fulfills json.Marshaler.
+func (t Transformation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transformationJSON(t))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (t *Transformation) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*transformationJSON)(t))
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the Transformation
+// as 48 bytes.
+func (t Transformation) MarshalBinary() ([]byte, error) {
+	return appendFBinary(appendFBinary(appendFBinary(nil, t.Translation), t.X), t.Y), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (t *Transformation) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	v := Transformation{
+		Translation: r.f(),
+		X:           r.f(),
+		Y:           r.f(),
+	}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The center and radius are
+// written as "(X, Y):R".
+func (c Circle) MarshalText() ([]byte, error) {
+	b := appendFText(nil, c.Centroid())
+	b = append(b, ':')
+	return appendFloatText(b, c.Radius()), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (c *Circle) UnmarshalText(text []byte) error {
+	strs := strings.Split(string(text), ":")
+	if len(strs) != 2 {
+		return newParseErr("Circle", string(text), "expected a center and radius")
+	}
+	center, err := ParseF(strs[0])
+	if err != nil {
+		return err
+	}
+	r, err := parseFloats("Circle", string(text), strs[1:])
+	if err != nil {
+		return err
+	}
+	*c = NewCircle(center, r[0])
+	return nil
+}
+
+type circleJSON struct {
+	Center F
+	Radius float64
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the Circle as an object with
+// Center and Radius.
+func (c Circle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(circleJSON{
+		Center: c.Centroid(),
+		Radius: c.Radius(),
+	})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (c *Circle) UnmarshalJSON(data []byte) error {
+	var v circleJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = NewCircle(v.Center, v.Radius)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the Circle as 24
+// bytes.
+func (c Circle) MarshalBinary() ([]byte, error) {
+	return appendFloatBinary(appendFBinary(nil, c.Centroid()), c.Radius()), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (c *Circle) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	center, radius := r.f(), r.float()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*c = NewCircle(center, radius)
+	return nil
+}
+
+// newEllipseArcFromAxis creates an EllipseArc directly from the values that
+// define it. This allows an EllipseArc to be decoded without rounding error.
+func newEllipseArcFromAxis(center F, major, minor, angle, start, length float64) EllipseArc {
+	e := EllipseArc{
+		Start:  start,
+		Length: length,
+		c:      center,
+		sMa:    major,
+		sma:    minor,
+		a:      angle,
+	}
+	e.as, e.ac = math.Sincos(angle)
+	return e
+}
+
+// newEllipseFromAxis creates an Ellipse directly from the values that define
+// it. This allows an Ellipse to be decoded without rounding error.
+func newEllipseFromAxis(center F, major, minor, angle float64) Ellipse {
+	return Ellipse{perimeter: newEllipseArcFromAxis(center, major, minor, angle, 0, math.Pi*2)}
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The ellipse is written as
+// "(X, Y):Major:Minor:Angle" where (X, Y) is the center, Major and Minor are
+// the semi-axis lengths and Angle is the angle of the major axis.
+func (e Ellipse) MarshalText() ([]byte, error) {
+	b := appendFText(nil, e.perimeter.c)
+	for _, f := range []float64{e.perimeter.sMa, e.perimeter.sma, e.perimeter.a} {
+		b = append(b, ':')
+		b = appendFloatText(b, f)
+	}
+	return b, nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (e *Ellipse) UnmarshalText(text []byte) error {
+	strs := strings.Split(string(text), ":")
+	if len(strs) != 4 {
+		return newParseErr("Ellipse", string(text), "expected a center, major, minor and angle")
+	}
+	center, err := ParseF(strs[0])
+	if err != nil {
+		return err
+	}
+	v, err := parseFloats("Ellipse", string(text), strs[1:])
+	if err != nil {
+		return err
+	}
+	*e = newEllipseFromAxis(center, v[0], v[1], v[2])
+	return nil
+}
+
+type ellipseJSON struct {
+	Center F
+	Major  float64
+	Minor  float64
+	Angle  float64
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the Ellipse as an object with
+// Center, Major, Minor and Angle.
+func (e Ellipse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ellipseJSON{
+		Center: e.perimeter.c,
+		Major:  e.perimeter.sMa,
+		Minor:  e.perimeter.sma,
+		Angle:  e.perimeter.a,
+	})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (e *Ellipse) UnmarshalJSON(data []byte) error {
+	var v ellipseJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = newEllipseFromAxis(v.Center, v.Major, v.Minor, v.Angle)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the Ellipse as 40
+// bytes.
+func (e Ellipse) MarshalBinary() ([]byte, error) {
+	b := appendFBinary(nil, e.perimeter.c)
+	b = appendFloatBinary(b, e.perimeter.sMa)
+	b = appendFloatBinary(b, e.perimeter.sma)
+	return appendFloatBinary(b, e.perimeter.a), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (e *Ellipse) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	center, major, minor, angle := r.f(), r.float(), r.float(), r.float()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*e = newEllipseFromAxis(center, major, minor, angle)
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The arc is written as
+// "(X, Y):Major:Minor:Angle:Start:Length", the same as Ellipse followed by the
+// Start and Length of the arc.
+func (e EllipseArc) MarshalText() ([]byte, error) {
+	b := appendFText(nil, e.c)
+	for _, f := range []float64{e.sMa, e.sma, e.a, e.Start, e.Length} {
+		b = append(b, ':')
+		b = appendFloatText(b, f)
+	}
+	return b, nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (e *EllipseArc) UnmarshalText(text []byte) error {
+	strs := strings.Split(string(text), ":")
+	if len(strs) != 6 {
+		return newParseErr("EllipseArc", string(text), "expected a center, major, minor, angle, start and length")
+	}
+	center, err := ParseF(strs[0])
+	if err != nil {
+		return err
+	}
+	v, err := parseFloats("EllipseArc", string(text), strs[1:])
+	if err != nil {
+		return err
+	}
+	*e = newEllipseArcFromAxis(center, v[0], v[1], v[2], v[3], v[4])
+	return nil
+}
+
+type ellipseArcJSON struct {
+	Center F
+	Major  float64
+	Minor  float64
+	Angle  float64
+	Start  float64
+	Length float64
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the EllipseArc as an object
+// with Center, Major, Minor, Angle, Start and Length.
+func (e EllipseArc) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ellipseArcJSON{
+		Center: e.c,
+		Major:  e.sMa,
+		Minor:  e.sma,
+		Angle:  e.a,
+		Start:  e.Start,
+		Length: e.Length,
+	})
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (e *EllipseArc) UnmarshalJSON(data []byte) error {
+	var v ellipseArcJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = newEllipseArcFromAxis(v.Center, v.Major, v.Minor, v.Angle, v.Start, v.Length)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the EllipseArc as
+// 56 bytes.
+func (e EllipseArc) MarshalBinary() ([]byte, error) {
+	b := appendFBinary(nil, e.c)
+	for _, f := range []float64{e.sMa, e.sma, e.a, e.Start, e.Length} {
+		b = appendFloatBinary(b, f)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (e *EllipseArc) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	center := r.f()
+	major, minor, angle, start, length := r.float(), r.float(), r.float(), r.float(), r.float()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*e = newEllipseArcFromAxis(center, major, minor, angle, start, length)
+	return nil
+}
+
+// newBezierPathFromPoints returns the zero BezierPath when there are no points
+// instead of panicking.
+func newBezierPathFromPoints(fs []F) BezierPath {
+	if len(fs) == 0 {
+		return BezierPath{}
+	}
+	return NewBezierPath(fs...)
+}
+
+// MarshalText fulfills encoding.TextMarshaler, writing the control points in
+// the same format as Polygon.
+func (bp BezierPath) MarshalText() ([]byte, error) {
+	return appendFsText(nil, bp.ps), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (bp *BezierPath) UnmarshalText(text []byte) error {
+	fs, err := parseFs("BezierPath", string(text))
+	if err == nil {
+		*bp = newBezierPathFromPoints(fs)
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the control points as a list
+// of [X, Y] pairs.
+func (bp BezierPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bp.Points())
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (bp *BezierPath) UnmarshalJSON(data []byte) error {
+	var fs []F
+	if err := json.Unmarshal(data, &fs); err != nil {
+		return err
+	}
+	*bp = newBezierPathFromPoints(fs)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the control points
+// in the same format as Polygon.
+func (bp BezierPath) MarshalBinary() ([]byte, error) {
+	return appendFsBinary(nil, bp.ps), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (bp *BezierPath) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	fs := r.fs()
+	if err := r.done(); err != nil {
+		return err
+	}
+	*bp = newBezierPathFromPoints(fs)
+	return nil
+}
+
+// newConcavePolygonFromPoints returns the zero ConcavePolygon when there are no
+// vertexes instead of panicking.
+func newConcavePolygonFromPoints(p Polygon) ConcavePolygon {
+	if len(p) == 0 {
+		return ConcavePolygon{}
+	}
+	return NewConcavePolygon(p)
+}
+
+// MarshalText fulfills encoding.TextMarshaler. Only the vertexes are written,
+// in the same format as Polygon, the triangles are found again when decoding.
+func (c ConcavePolygon) MarshalText() ([]byte, error) {
+	return c.concave.MarshalText()
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (c *ConcavePolygon) UnmarshalText(text []byte) error {
+	var p Polygon
+	err := p.UnmarshalText(text)
+	if err == nil {
+		*c = newConcavePolygonFromPoints(p)
+	}
+	return err
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the vertexes the same as
+// Polygon.
+func (c ConcavePolygon) MarshalJSON() ([]byte, error) {
+	return c.concave.MarshalJSON()
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (c *ConcavePolygon) UnmarshalJSON(data []byte) error {
+	var p Polygon
+	err := p.UnmarshalJSON(data)
+	if err == nil {
+		*c = newConcavePolygonFromPoints(p)
+	}
+	return err
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the vertexes the
+// same as Polygon.
+func (c ConcavePolygon) MarshalBinary() ([]byte, error) {
+	return c.concave.MarshalBinary()
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (c *ConcavePolygon) UnmarshalBinary(data []byte) error {
+	var p Polygon
+	err := p.UnmarshalBinary(data)
+	if err == nil {
+		*c = newConcavePolygonFromPoints(p)
+	}
+	return err
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The control points of each
+// segment are written in the same format as Polygon with the segments
+// separated by semicolons.
+func (cb CompositeBezier) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, pts := range cb.points {
+		if i > 0 {
+			b = append(b, ';')
+		}
+		b = appendFsText(b, pts)
+	}
+	return b, nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (cb *CompositeBezier) UnmarshalText(text []byte) error {
+	var points [][]F
+	if strings.TrimSpace(string(text)) != "" {
+		for i, str := range strings.Split(string(text), ";") {
+			fs, err := parseFs("CompositeBezier", str)
+			if err != nil {
+				return err
+			}
+			if len(fs) == 0 {
+				return newParseErr("CompositeBezier", string(text), "segment "+strconv.Itoa(i)+" has no control points")
+			}
+			points = append(points, fs)
+		}
+	}
+	*cb = newCompositeBezier(points)
+	return nil
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the control points of each
+// segment as a list of [X, Y] pairs.
+func (cb CompositeBezier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cb.points)
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (cb *CompositeBezier) UnmarshalJSON(data []byte) error {
+	var points [][]F
+	if err := json.Unmarshal(data, &points); err != nil {
+		return err
+	}
+	for _, pts := range points {
+		if len(pts) == 0 {
+			return errEmptyCurve
+		}
+	}
+	*cb = newCompositeBezier(points)
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler. The number of segments is
+// written as a uvarint followed by the control points of each segment in the
+// same format as Polygon.
+func (cb CompositeBezier) MarshalBinary() ([]byte, error) {
+	b := binary.AppendUvarint(nil, uint64(len(cb.points)))
+	for _, pts := range cb.points {
+		b = appendFsBinary(b, pts)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (cb *CompositeBezier) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	// each segment takes at least 1 byte for its length
+	points := make([][]F, r.count(1))
+	for i := range points {
+		points[i] = r.fs()
+		if r.err == nil && len(points[i]) == 0 {
+			r.err = errEmptyCurve
+		}
+	}
+	if err := r.done(); err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		points = nil
+	}
+	*cb = newCompositeBezier(points)
+	return nil
+}
+
+// checkTangents returns errTangents if h does not have a tangent in In and Out
+// for each point.
+func (h Hermite) checkTangents() error {
+	if len(h.In) != len(h.Points) || len(h.Out) != len(h.Points) {
+		return errTangents
+	}
+	return nil
+}
+
+// MarshalText fulfills encoding.TextMarshaler. The Points, In and Out are
+// each written in the same format as Polygon, separated by semicolons.
+func (h Hermite) MarshalText() ([]byte, error) {
+	b := appendFsText(nil, h.Points)
+	b = append(b, ';')
+	b = appendFsText(b, h.In)
+	b = append(b, ';')
+	return appendFsText(b, h.Out), nil
+}
+
+// UnmarshalText fulfills encoding.TextUnmarshaler.
+func (h *Hermite) UnmarshalText(text []byte) error {
+	strs := strings.Split(string(text), ";")
+	if len(strs) != 3 {
+		return newParseErr("Hermite", string(text), "expected points, in and out tangents")
+	}
+	var v [3][]F
+	for i, str := range strs {
+		fs, err := parseFs("Hermite", str)
+		if err != nil {
+			return err
+		}
+		v[i] = fs
+	}
+	d := Hermite{Points: v[0], In: v[1], Out: v[2]}
+	if d.checkTangents() != nil {
+		return newParseErr("Hermite", string(text), "expected a tangent for each point")
+	}
+	*h = d
+	return nil
+}
+
+// hermiteJSON has the same fields as Hermite without the methods so it can be
+// encoded by reflection.
+type hermiteJSON struct {
+	Points  []F
+	In, Out []F
+}
+
+// MarshalJSON fulfills json.Marshaler, encoding the Hermite as an object with
+// Points, In and Out.
+func (h Hermite) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hermiteJSON(h))
+}
+
+// UnmarshalJSON fulfills json.Unmarshaler.
+func (h *Hermite) UnmarshalJSON(data []byte) error {
+	var v hermiteJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d := Hermite(v)
+	if err := d.checkTangents(); err != nil {
+		return err
+	}
+	*h = d
+	return nil
+}
+
+// MarshalBinary fulfills encoding.BinaryMarshaler, encoding the Points, In and
+// Out in that order, each in the same format as Polygon.
+func (h Hermite) MarshalBinary() ([]byte, error) {
+	b := appendFsBinary(nil, h.Points)
+	b = appendFsBinary(b, h.In)
+	return appendFsBinary(b, h.Out), nil
+}
+
+// UnmarshalBinary fulfills encoding.BinaryUnmarshaler.
+func (h *Hermite) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	d := Hermite{Points: r.fs(), In: r.fs(), Out: r.fs()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	if err := d.checkTangents(); err != nil {
+		return err
+	}
+	*h = d
+	return nil
+}
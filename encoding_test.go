@@ -0,0 +1,260 @@
+package vec2d
+
+import (
+	"encoding"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type codec interface {
+	encoding.TextMarshaler
+	encoding.BinaryMarshaler
+	json.Marshaler
+}
+
+type decoder interface {
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+	json.Unmarshaler
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	tt := map[string]struct {
+		in  codec
+		out func() decoder
+	}{
+		"F": {
+			in:  F{0.1, -1.0 / 3.0},
+			out: func() decoder { return &F{} },
+		},
+		"I": {
+			in:  I{-5, 1 << 40},
+			out: func() decoder { return &I{} },
+		},
+		"P": {
+			in:  P{2.5, Pi / 3},
+			out: func() decoder { return &P{} },
+		},
+		"Polygon": {
+			in:  RegularPolygonRadius(F{1, 2}, 3, 0.1, 7),
+			out: func() decoder { return &Polygon{} },
+		},
+		"LineSegments": {
+			in:  LineSegments{{0, 0}, {1.5, 2}, {1e-20, 3e20}},
+			out: func() decoder { return &LineSegments{} },
+		},
+		"Triangle": {
+			in:  Triangle{{0, 0}, {1.1, 0}, {0, 1.0 / 7.0}},
+			out: func() decoder { return &Triangle{} },
+		},
+		"Transformation": {
+			in: Transformation{
+				Translation: F{1, 2},
+				X:           F{0.3, 0.4},
+				Y:           F{-0.4, 0.3},
+			},
+			out: func() decoder { return &Transformation{} },
+		},
+		"Circle": {
+			in:  NewCircle(F{1.1, 2.2}, 3.3),
+			out: func() decoder { return &Circle{} },
+		},
+		"Ellipse": {
+			in:  NewEllipse(F{1, 1}, F{2, 3}, 0.7),
+			out: func() decoder { return &Ellipse{} },
+		},
+		"EllipseArc": {
+			in: func() EllipseArc {
+				e := NewEllipseArc(F{1, 1}, F{2, 3}, 0.7)
+				e.Start, e.Length = 0.5, 1.2
+				return e
+			}(),
+			out: func() decoder { return &EllipseArc{} },
+		},
+		"ConcavePolygon": {
+			in:  NewConcavePolygon(Polygon{{0, 0}, {2, 0}, {1, 0.5}, {2, 2}, {0, 2}}),
+			out: func() decoder { return &ConcavePolygon{} },
+		},
+		"Hermite": {
+			in:  NewCatmullRom(F{0, 0}, F{1, 2}, F{3, 1.0 / 3.0}),
+			out: func() decoder { return &Hermite{} },
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			txt, err := tc.in.MarshalText()
+			assert.NoError(t, err)
+			d := tc.out()
+			assert.NoError(t, d.UnmarshalText(txt))
+			assert.Equal(t, tc.in, deref(d), string(txt))
+
+			b, err := tc.in.MarshalBinary()
+			assert.NoError(t, err)
+			d = tc.out()
+			assert.NoError(t, d.UnmarshalBinary(b))
+			assert.Equal(t, tc.in, deref(d))
+			assert.Error(t, tc.out().UnmarshalBinary(b[:len(b)-1]))
+			assert.Error(t, tc.out().UnmarshalBinary(append(b, 0)))
+
+			j, err := json.Marshal(tc.in)
+			assert.NoError(t, err)
+			d = tc.out()
+			assert.NoError(t, json.Unmarshal(j, d))
+			assert.Equal(t, tc.in, deref(d), string(j))
+		})
+	}
+}
+
+func deref(d decoder) codec {
+	switch v := d.(type) {
+	case *F:
+		return *v
+	case *I:
+		return *v
+	case *P:
+		return *v
+	case *Polygon:
+		return *v
+	case *LineSegments:
+		return *v
+	case *Triangle:
+		return *v
+	case *Transformation:
+		return *v
+	case *Circle:
+		return *v
+	case *Ellipse:
+		return *v
+	case *EllipseArc:
+		return *v
+	case *ConcavePolygon:
+		return *v
+	case *Hermite:
+		return *v
+	}
+	return nil
+}
+
+func TestEncodingFormats(t *testing.T) {
+	b, _ := F{1.5, 2}.MarshalText()
+	assert.Equal(t, "(1.5, 2)", string(b))
+	b, _ = json.Marshal(Polygon{{0, 0}, {1, 0}, {0, 1}})
+	assert.Equal(t, "[[0,0],[1,0],[0,1]]", string(b))
+	b, _ = json.Marshal(NewCircle(F{1, 2}, 3))
+	assert.Equal(t, `{"Center":[1,2],"Radius":3}`, string(b))
+	b, _ = json.Marshal(map[I]int{{1, 2}: 3})
+	assert.Equal(t, `{"(1, 2)":3}`, string(b))
+	b, _ = Polygon{{0, 0}, {1, 0}, {0, 1}}.MarshalBinary()
+	assert.Len(t, b, 1+3*16)
+
+	var p P
+	assert.NoError(t, p.UnmarshalText([]byte("(1.0000, 2.0000 rad )")))
+	assert.Equal(t, P{1, 2}, p)
+
+	var f F
+	assert.Error(t, f.UnmarshalText([]byte("(1, 2")))
+	assert.Error(t, f.UnmarshalText([]byte("(1; 2)")))
+	var tri Triangle
+	assert.Error(t, tri.UnmarshalText([]byte("(1, 2):(3, 4)")))
+}
+
+func TestEncodingBezierPath(t *testing.T) {
+	bp := NewBezierPath(F{0, 0}, F{1, 2.5}, F{3, 1.0 / 3.0})
+	var d BezierPath
+
+	txt, err := bp.MarshalText()
+	assert.NoError(t, err)
+	assert.NoError(t, d.UnmarshalText(txt))
+	assert.Equal(t, bp.Points(), d.Points())
+	assert.Equal(t, bp.F(0.3), d.F(0.3))
+
+	b, err := bp.MarshalBinary()
+	assert.NoError(t, err)
+	d = BezierPath{}
+	assert.NoError(t, d.UnmarshalBinary(b))
+	assert.Equal(t, bp.Points(), d.Points())
+	assert.Error(t, d.UnmarshalBinary(b[:len(b)-1]))
+
+	j, err := json.Marshal(bp)
+	assert.NoError(t, err)
+	assert.Equal(t, "[[0,0],[1,2.5],[3,0.3333333333333333]]", string(j))
+	d = BezierPath{}
+	assert.NoError(t, json.Unmarshal(j, &d))
+	assert.Equal(t, bp.Points(), d.Points())
+
+	assert.NoError(t, json.Unmarshal([]byte("[]"), &d))
+	assert.Len(t, d.Points(), 0)
+}
+
+func TestEncodingCompositeBezier(t *testing.T) {
+	cb := NewRelativeCompositeBezier([]CompositeBezierSegment{
+		{{0, 1}, {-1, 0}, {2, 2}},
+		{{1, 0}, {0, -1.0 / 3.0}, {2, -2}},
+	}, IdentityTransformation())
+	var d CompositeBezier
+
+	txt, err := cb.MarshalText()
+	assert.NoError(t, err)
+	assert.NoError(t, d.UnmarshalText(txt))
+	assert.Equal(t, cb.Points(), d.Points())
+	assert.Equal(t, cb.F(0.3), d.F(0.3))
+
+	b, err := cb.MarshalBinary()
+	assert.NoError(t, err)
+	d = CompositeBezier{}
+	assert.NoError(t, d.UnmarshalBinary(b))
+	assert.Equal(t, cb.Points(), d.Points())
+	assert.Error(t, d.UnmarshalBinary(b[:len(b)-1]))
+
+	j, err := json.Marshal(cb)
+	assert.NoError(t, err)
+	d = CompositeBezier{}
+	assert.NoError(t, json.Unmarshal(j, &d))
+	assert.Equal(t, cb.Points(), d.Points())
+
+	// a segment without control points cannot be evaluated
+	assert.Equal(t, errEmptyCurve, json.Unmarshal([]byte("[[[0,0]],[]]"), &d))
+	assert.Equal(t, errEmptyCurve, d.UnmarshalBinary([]byte{1, 0}))
+	assert.Error(t, d.UnmarshalText([]byte("(0, 0);")))
+
+	assert.NoError(t, json.Unmarshal([]byte("[]"), &d))
+	assert.Len(t, d.Points(), 0)
+	txt, _ = d.MarshalText()
+	assert.NoError(t, d.UnmarshalText(txt))
+	assert.Len(t, d.Points(), 0)
+}
+
+func TestEncodingInvalid(t *testing.T) {
+	var h Hermite
+	assert.Equal(t, errTangents, json.Unmarshal([]byte(`{"Points":[[0,0],[1,1]],"In":[[1,0]],"Out":[[1,0],[1,0]]}`), &h))
+	b, _ := Hermite{Points: []F{{0, 0}}}.MarshalBinary()
+	assert.Equal(t, errTangents, h.UnmarshalBinary(b))
+	assert.Error(t, h.UnmarshalText([]byte("(0, 0):(1, 1);(1, 0);(1, 0)")))
+	assert.Error(t, h.UnmarshalText([]byte("(0, 0);(1, 0)")))
+
+	// the zero ConcavePolygon has no vertexes
+	var c ConcavePolygon
+	assert.NoError(t, json.Unmarshal([]byte("[]"), &c))
+	assert.Equal(t, ConcavePolygon{}, c)
+}
+
+func TestEncodingJSONLength(t *testing.T) {
+	for _, data := range []string{"[1]", "[1,2,3]", "[]"} {
+		var f F
+		assert.Equal(t, errJSONLength, json.Unmarshal([]byte(data), &f), data)
+		var i I
+		assert.Equal(t, errJSONLength, json.Unmarshal([]byte(data), &i), data)
+		var p P
+		assert.Equal(t, errJSONLength, json.Unmarshal([]byte(data), &p), data)
+	}
+	var f F
+	assert.Error(t, json.Unmarshal([]byte(`["a","b"]`), &f))
+
+	var tri Triangle
+	assert.Equal(t, errJSONLength, json.Unmarshal([]byte("[[0,0],[1,0]]"), &tri))
+	assert.Equal(t, errJSONLength, json.Unmarshal([]byte("[[0,0],[1,0],[0,1],[1,1]]"), &tri))
+	assert.Error(t, json.Unmarshal([]byte("[[0,0],[1,0],[0]]"), &tri))
+	assert.Equal(t, Triangle{}, tri)
+}
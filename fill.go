@@ -0,0 +1,97 @@
+package vec2d
+
+import (
+	"math"
+	"sort"
+)
+
+// Fill returns an iterator over every integer point inside the polygon using
+// scanline filling. The points are visited a full row at a time, from the
+// lowest Y to the highest.
+//
+// Points that lie exactly on the perimeter follow the top-left convention: a
+// point on a side with the least Y (top) or on the left side of a span is
+// included and a point on a side with the greatest Y (bottom) or on the right
+// side of a span is excluded. This means that polygons that share a side,
+// such as the triangles from FindTriangles, never both include a point and
+// never both exclude a point on that side. If the sides intersect, points are
+// included using the even-odd rule, the same as Contains.
+func (p Polygon) Fill() IntIterator {
+	return newSpanIterator(p.spans())
+}
+
+// Fill returns an iterator over every integer point inside the polygon. See
+// Polygon.Fill.
+func (c ConcavePolygon) Fill() IntIterator {
+	return c.concave.Fill()
+}
+
+// Fill returns an iterator over every integer point inside the triangle. See
+// Polygon.Fill.
+func (t Triangle) Fill() IntIterator {
+	return Polygon(t[:]).Fill()
+}
+
+// edge is a non-horizontal side of a polygon stored with the lower Y point
+// first so that the X intercept computed on a row is the same regardless of
+// which polygon the side belongs to.
+type edge struct {
+	lo, hi F
+}
+
+func (e edge) x(y float64) float64 {
+	return e.lo.X + (y-e.lo.Y)*(e.hi.X-e.lo.X)/(e.hi.Y-e.lo.Y)
+}
+
+func (p Polygon) spans() []span {
+	if len(p) < 3 {
+		return nil
+	}
+	edges := make([]edge, 0, len(p))
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	prev := p[len(p)-1]
+	for _, cur := range p {
+		minY, maxY = math.Min(minY, cur.Y), math.Max(maxY, cur.Y)
+		switch {
+		case prev.Y < cur.Y:
+			edges = append(edges, edge{prev, cur})
+		case prev.Y > cur.Y:
+			edges = append(edges, edge{cur, prev})
+		}
+		prev = cur
+	}
+	// sort by the lower Y so each row only has to consider the active edges
+	sort.Slice(edges, func(i, j int) bool { return edges[i].lo.Y < edges[j].lo.Y })
+
+	var spans []span
+	var active []edge
+	var xs []float64
+	next := 0
+	for y := math.Ceil(minY); y < maxY; y++ {
+		for ; next < len(edges) && edges[next].lo.Y <= y; next++ {
+			active = append(active, edges[next])
+		}
+		// an edge includes its lower point and excludes its upper point
+		keep := active[:0]
+		for _, e := range active {
+			if e.hi.Y > y {
+				keep = append(keep, e)
+			}
+		}
+		active = keep
+
+		xs = xs[:0]
+		for _, e := range active {
+			xs = append(xs, e.x(y))
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			spans = append(spans, span{
+				Y:  int(y),
+				X0: int(math.Ceil(xs[i])),
+				X1: int(math.Ceil(xs[i+1])),
+			})
+		}
+	}
+	return spans
+}
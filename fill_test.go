@@ -0,0 +1,59 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPolygonFill(t *testing.T) {
+	square := RectangleToPoints(F{0, 0}, F{3, 2})
+	expected := []I{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}}
+	it := square.Fill()
+	assert.Equal(t, 6, it.Area())
+	assert.Equal(t, expected, it.Slice())
+	assert.Equal(t, expected, square.Reverse().Fill().Slice())
+
+	shifted := RectangleToPoints(F{0.5, 0.5}, F{3.5, 2.5})
+	assert.Equal(t, []I{{1, 1}, {2, 1}, {3, 1}, {1, 2}, {2, 2}, {3, 2}}, shifted.Fill().Slice())
+
+	assert.Equal(t, 0, Polygon{{0, 0}, {1, 1}}.Fill().Area())
+}
+
+func TestFillMatchesContains(t *testing.T) {
+	ps := []Polygon{
+		RegularPolygonRadius(F{10.3, 9.7}, 8.2, 0.3, 7),
+		{{0.5, 0.5}, {20.5, 3.5}, {10.5, 10.5}, {18.5, 18.5}, {1.5, 15.5}},
+		{{0.5, 0.5}, {10.5, 10.5}, {10.5, 0.5}, {0.5, 10.5}},
+	}
+	for _, p := range ps {
+		filled := make(map[I]bool)
+		p.Fill().Each(func(_ int, pt I) {
+			filled[pt] = true
+		})
+		assert.Equal(t, p.Fill().Area(), len(filled))
+		for it, pt, ok := (I{-1, -1}).To(I{22, 22}).Start(); ok; pt, ok = it.Next() {
+			// none of these polygons have a side passing through an integer
+			// point, so Contains and Fill should agree everywhere
+			assert.Equal(t, p.Contains(pt.F()), filled[pt], pt)
+		}
+	}
+}
+
+func TestFillSharedEdges(t *testing.T) {
+	p := Polygon{{0, 0}, {12, 0}, {12, 4}, {6, 3}, {9, 9}, {0, 12}, {3, 6}}
+	cp := NewConcavePolygon(p)
+	whole := make(map[I]bool)
+	cp.Fill().Each(func(_ int, pt I) { whole[pt] = true })
+
+	seen := make(map[I]int)
+	for _, tri := range GetTriangles(p.FindTriangles(), p) {
+		tri.Fill().Each(func(_ int, pt I) {
+			seen[pt]++
+		})
+	}
+	for pt, c := range seen {
+		assert.Equal(t, 1, c, "point %v filled by %d triangles", pt, c)
+		assert.True(t, whole[pt], pt)
+	}
+	assert.Equal(t, len(whole), len(seen))
+}
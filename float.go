@@ -97,6 +97,91 @@ func (f F) Dot(f2 F) float64 {
 	return f.X*f2.X + f.Y*f2.Y
 }
 
+// Normalize returns a vector with the same angle as f and a magnitude of 1. The
+// zero vector is returned unchanged.
+func (f F) Normalize() F {
+	m := f.Mag()
+	if m == 0 {
+		return f
+	}
+	return F{f.X / m, f.Y / m}
+}
+
+// Project returns the component of f that lies along onto. If onto is the zero
+// vector, the zero vector is returned.
+func (f F) Project(onto F) F {
+	d := onto.Mag2()
+	if d == 0 {
+		return F{}
+	}
+	return onto.ScalarMultiply(f.Dot(onto) / d)
+}
+
+// Reject returns the component of f that is perpendicular to onto, so that
+// f.Project(onto).Add(f.Reject(onto)) == f.
+func (f F) Reject(onto F) F {
+	return f.Subtract(f.Project(onto))
+}
+
+// Reflect returns f reflected across a surface with the given normal. The
+// normal does not need to be a unit vector.
+func (f F) Reflect(normal F) F {
+	return f.Subtract(f.Project(normal).ScalarMultiply(2))
+}
+
+// Perpendicular returns f rotated a quarter turn counter-clockwise.
+func (f F) Perpendicular() F {
+	return F{-f.Y, f.X}
+}
+
+// AngleTo returns the signed angle in radians to rotate f onto f2. The value
+// is positive for counter-clockwise rotations and lies in the range [-π, π].
+func (f F) AngleTo(f2 F) float64 {
+	return math.Atan2(f.Cross(f2), f.Dot(f2))
+}
+
+// Lerp linearly interpolates between f at t=0 and f2 at t=1.
+func (f F) Lerp(f2 F, t float64) F {
+	return F{f.X + (f2.X-f.X)*t, f.Y + (f2.Y-f.Y)*t}
+}
+
+// Min returns the lesser X and the lesser Y of f and f2.
+func (f F) Min(f2 F) F {
+	return F{math.Min(f.X, f2.X), math.Min(f.Y, f2.Y)}
+}
+
+// Max returns the greater X and the greater Y of f and f2.
+func (f F) Max(f2 F) F {
+	return F{math.Max(f.X, f2.X), math.Max(f.Y, f2.Y)}
+}
+
+// Clamp limits each component of f to lie between the components of min and
+// max.
+func (f F) Clamp(min, max F) F {
+	return f.Max(min).Min(max)
+}
+
+// Abs takes the Abs of X and Y
+func (f F) Abs() F {
+	return F{math.Abs(f.X), math.Abs(f.Y)}
+}
+
+// Epsilon is the default tolerance used when float64 values need to be treated
+// as equal despite rounding error. It can be changed to suit the scale of the
+// data being worked with.
+var Epsilon = 1e-10
+
+// Near returns true if a and b are within eps of each other.
+func Near(a, b, eps float64) bool {
+	return math.Abs(a-b) <= eps
+}
+
+// Near returns true if both the X and Y values of f and f2 are within eps of
+// each other.
+func (f F) Near(f2 F, eps float64) bool {
+	return Near(f.X, f2.X, eps) && Near(f.Y, f2.Y, eps)
+}
+
 // I converts a float64 vector to an int vector. Will always round down.
 func (f F) I() I {
 	return I{int(f.X), int(f.Y)}
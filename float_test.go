@@ -83,6 +83,10 @@ func TestIntersect(t *testing.T) {
 	ss = F{8, 1}
 	se = F{6, 8}
 	assert.Equal(t, 0.625, MotionSurfaceIntersection(ms, me, ss, se))
+
+	// motion along the surface does not have a single time of intersection
+	assert.True(t, math.IsNaN(MotionSurfaceIntersection(F{0, 0}, F{4, 0}, F{3, 0}, F{1, 0})))
+	assert.True(t, math.IsNaN(MotionSurfaceIntersection(F{0, 0}, F{4, 0}, F{0, 1}, F{4, 1})))
 }
 
 func TestEmbed(t *testing.T) {
@@ -147,3 +151,38 @@ func TestStandardFloat(t *testing.T) {
 	// 1/4 rotation should be +Y
 	assert.InDelta(t, 0.0, P{1, Pi / 2}.F().Distance(F{0, 1}), 1E-10)
 }
+
+func TestNear(t *testing.T) {
+	a := F{1, 1}
+	b := a.Rotate(Pi / 3).Rotate(-Pi / 3)
+	assert.True(t, a.Near(b, Epsilon))
+	assert.False(t, a.Near(F{1, 1.1}, Epsilon))
+	assert.True(t, a.Near(F{1, 1.1}, 0.2))
+	assert.True(t, Near(1, 1+1e-12, Epsilon))
+	assert.False(t, Near(1, 1+1e-8, Epsilon))
+}
+
+func TestVectorAlgebra(t *testing.T) {
+	assert.Equal(t, F{0.6, 0.8}, F{3, 4}.Normalize())
+	assert.Equal(t, F{}, F{}.Normalize())
+
+	f := F{3, 4}
+	assert.Equal(t, F{3, 0}, f.Project(F{2, 0}))
+	assert.Equal(t, F{0, 4}, f.Reject(F{2, 0}))
+	assert.Equal(t, f, f.Project(F{5, 0}).Add(f.Reject(F{5, 0})))
+	assert.Equal(t, F{}, f.Project(F{}))
+
+	assert.Equal(t, F{3, -4}, f.Reflect(F{0, 2}))
+	assert.Equal(t, F{-4, 3}, f.Perpendicular())
+	assert.Equal(t, 0.0, f.Dot(f.Perpendicular()))
+
+	assert.InDelta(t, Pi/2, F{1, 0}.AngleTo(F{0, 2}), 1e-10)
+	assert.InDelta(t, -Pi/2, F{1, 0}.AngleTo(F{0, -2}), 1e-10)
+	assert.InDelta(t, Pi/4, F{0, 1}.AngleTo(F{-1, 1}), 1e-10)
+
+	assert.Equal(t, F{2, 3}, F{0, 2}.Lerp(F{4, 4}, 0.5))
+	assert.Equal(t, F{1, 2}, F{1, 5}.Min(F{3, 2}))
+	assert.Equal(t, F{3, 5}, F{1, 5}.Max(F{3, 2}))
+	assert.Equal(t, F{0, 1}, F{-1, 3}.Clamp(F{0, 0}, F{1, 1}))
+	assert.Equal(t, F{1, 2}, F{-1, 2}.Abs())
+}
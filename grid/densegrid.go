@@ -19,8 +19,8 @@ func NewDenseGrid(size vec2d.I, generator Generator) *DenseGrid {
 		Data: make([]interface{}, size.Area()),
 	}
 	if generator != nil {
-		for iter, pt, ok := g.Size.FromOrigin().Start(); ok; pt, ok = iter.Next() {
-			g.Data[iter.Idx()] = generator(pt)
+		for idx, pt := range vec2d.All(g.Size.FromOrigin()) {
+			g.Data[idx] = generator(pt)
 		}
 	}
 	return g
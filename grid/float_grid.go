@@ -37,7 +37,7 @@ func Diamond(startSize vec2d.I, iterations int, noiseDecay float64) *DenseGrid {
 	gen := newDiamondGenerator(startSize, iterations, 1.0, noiseDecay)
 
 	// populate the initial points
-	for iter, pt, ok := startSize.FromOrigin().Start(); ok; pt, ok = iter.Next() {
+	for _, pt := range vec2d.All(startSize.FromOrigin()) {
 		p2 := pt.ScalarMultiply(gen.scale)
 		idx := gen.size.Idx(p2)
 		gen.grid[idx] = rand.Float64()
@@ -117,7 +117,7 @@ func Gradient(start *DenseGrid, iterations int) *DenseGrid {
 	gen := newDiamondGenerator(start.Size, iterations, 0, 0)
 
 	// populate the initial points
-	for iter, pt, ok := start.Size.FromOrigin().Start(); ok; pt, ok = iter.Next() {
+	for _, pt := range vec2d.All(start.Size.FromOrigin()) {
 		p2 := pt.ScalarMultiply(gen.scale)
 		idx := gen.size.Idx(p2)
 		gen.grid[idx] = start.Get(pt).(float64)
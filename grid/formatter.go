@@ -3,6 +3,8 @@ package grid
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/adamcolton/vec2d"
 )
 
 // Formatter is used to format a grid to a string
@@ -36,9 +38,9 @@ func (f Formatter) Format(g Grid) string {
 	sz := g.GetSize()
 	widths := make([]int, sz.X)
 	strs := make([]string, sz.Area())
-	for iter, pt, ok := sz.FromOrigin().Start(); ok; pt, ok = iter.Next() {
+	for idx, pt := range vec2d.All(sz.FromOrigin()) {
 		s := stringer(g.Get(pt))
-		strs[iter.Idx()] = s
+		strs[idx] = s
 		if l := len([]rune(s)); l > widths[pt.X] {
 			widths[pt.X] = l
 		}
@@ -54,13 +56,13 @@ func (f Formatter) Format(g Grid) string {
 		widthFmt[i] = a(w)
 	}
 	var buf bytes.Buffer
-	for iter, pt, ok := sz.FromOrigin().Start(); ok; pt, ok = iter.Next() {
+	for idx, pt := range vec2d.All(sz.FromOrigin()) {
 		if pt.X == 0 {
 			buf.WriteString("\n")
 		} else {
 			buf.WriteString(f.Separator)
 		}
-		buf.WriteString(fmt.Sprintf(widthFmt[pt.X], strs[iter.Idx()]))
+		buf.WriteString(fmt.Sprintf(widthFmt[pt.X], strs[idx]))
 	}
 	return buf.String()
 }
@@ -5,7 +5,7 @@ import (
 )
 
 var origin vec2d.I
-var dirs = vec2d.I{-1, -1}.To(vec2d.I{2, 2}).Slice()
+var dirs = vec2d.Moore(1).Offsets()
 
 // Generator is used to populate a grid
 type Generator func(pt vec2d.I) interface{}
@@ -20,6 +20,7 @@ type Grid interface {
 // Flood takes a grid and beginning at start floods out in every direction in
 // dirs, checking each point against include. If a point is included, it will
 // flood out from there. All points that are included are returned as a slice.
+// The Offsets of a vec2d.Neighborhood can be used for dirs.
 func Flood(g Grid, start vec2d.I, dirs []vec2d.I, include func(pt vec2d.I, g Grid) bool) []vec2d.I {
 	var ret []vec2d.I
 	seen := map[vec2d.I]bool{
@@ -127,3 +127,57 @@ func TestFormatter(t *testing.T) {
 	str := f.Format(NewDenseGrid(vec2d.I{10, 10}, generator))
 	assert.Equal(t, 90, strings.Count(str, "|"))
 }
+
+func TestFloodNeighborhood(t *testing.T) {
+	// a diagonal line of 1s is connected in a Moore neighborhood but not in a
+	// von Neumann neighborhood
+	g := NewDenseGrid(vec2d.I{5, 5}, func(pt vec2d.I) interface{} {
+		if pt.X == pt.Y {
+			return 1
+		}
+		return 0
+	})
+	include := func(pt vec2d.I, g Grid) bool { return g.Get(pt).(int) == 1 }
+
+	n := vec2d.VonNeumann(1)
+	n.ExcludeCenter = true
+	assert.Len(t, Flood(g, vec2d.I{0, 0}, n.Offsets(), include), 1)
+
+	n = vec2d.Moore(1)
+	n.ExcludeCenter = true
+	assert.Len(t, Flood(g, vec2d.I{0, 0}, n.Offsets(), include), 5)
+}
+
+func TestAll(t *testing.T) {
+	gen := func(pt vec2d.I) interface{} {
+		return float64(pt.X * pt.Y)
+	}
+	g := NewDenseGrid(vec2d.I{3, 4}, gen)
+	var count int
+	for pt, f := range All[float64](g) {
+		assert.Equal(t, gen(pt), f)
+		count++
+	}
+	assert.Equal(t, 12, count)
+
+	// pointers are dereferenced
+	g = NewDenseGrid(vec2d.I{3, 3}, func(pt vec2d.I) interface{} {
+		v := gen(pt).(float64)
+		return &v
+	})
+	for pt, f := range Range[float64](g, vec2d.I{1, 1}.To(vec2d.I{3, 3})) {
+		assert.Equal(t, gen(pt), f)
+	}
+
+	// nil values are returned as the zero value
+	g = NewDenseGrid(vec2d.I{2, 2}, nil)
+	for _, f := range All[float64](g) {
+		assert.Equal(t, 0.0, f)
+	}
+
+	g = NewDenseGrid(vec2d.I{2, 2}, func(pt vec2d.I) interface{} { return "x" })
+	assert.Panics(t, func() {
+		for range All[float64](g) {
+		}
+	})
+}
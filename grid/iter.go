@@ -8,6 +8,8 @@ import (
 // Iter is a helper for iterating over a grid. Iter uses reflection for type
 // checking. When Iter is constructed, it will be passed a reference value that
 // must be a pointer. Each time Next is called, that pointer will be updated.
+// All and Range provide the same traversal without reflection for use with
+// range.
 type Iter struct {
 	g       Grid
 	intIter vec2d.IntIterator
@@ -19,7 +19,7 @@ func Process(in, out Grid, processor Processor) Grid {
 		}
 	}
 
-	for iter, pt, ok := sz.FromOrigin().Start(); ok; pt, ok = iter.Next() {
+	for _, pt := range vec2d.All(sz.FromOrigin()) {
 		out.Set(pt, processor(pt, in))
 	}
 
@@ -0,0 +1,45 @@
+package grid
+
+import (
+	"github.com/adamcolton/vec2d"
+	"iter"
+)
+
+// All returns a sequence of every point in the grid and the value at that point
+// for use with range. Values stored as either T or *T are returned as T and nil
+// values are returned as the zero value of T. Any other type will panic.
+//
+//	for pt, f := range grid.All[float64](g) {
+//	  fmt.Println(pt, f)
+//	}
+func All[T any](g Grid) iter.Seq2[vec2d.I, T] {
+	return Range[T](g, g.GetSize().FromOrigin())
+}
+
+// Range returns a sequence of the points visited by intIter and the value in
+// the grid at each point. It handles values the same way as All.
+func Range[T any](g Grid, intIter vec2d.IntIterator) iter.Seq2[vec2d.I, T] {
+	return func(yield func(vec2d.I, T) bool) {
+		for _, pt := range vec2d.All(intIter) {
+			if !yield(pt, value[T](g.Get(pt))) {
+				return
+			}
+		}
+	}
+}
+
+func value[T any](v interface{}) T {
+	switch t := v.(type) {
+	case T:
+		return t
+	case *T:
+		if t != nil {
+			return *t
+		}
+	case nil:
+	default:
+		panic("types don't match")
+	}
+	var zero T
+	return zero
+}
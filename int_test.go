@@ -94,3 +94,33 @@ func TestIterChan(t *testing.T) {
 		expected = expected[1:]
 	}
 }
+
+func TestIterAll(t *testing.T) {
+	it := I{1, 2}.To(I{3, 4})
+	expected := it.Slice()
+	var count int
+	for idx, pt := range All(it) {
+		assert.Equal(t, count, idx)
+		assert.Equal(t, expected[idx], pt)
+		count++
+	}
+	assert.Equal(t, len(expected), count)
+
+	// the method on IterBaseWrapper is the same sequence
+	count = 0
+	for idx, pt := range it.(IterBaseWrapper).All() {
+		assert.Equal(t, expected[idx], pt)
+		count++
+	}
+	assert.Equal(t, len(expected), count)
+
+	// breaking out of the loop stops iteration
+	count = 0
+	for range All(it) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+}
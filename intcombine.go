@@ -0,0 +1,523 @@
+package vec2d
+
+// The functions in this file compose IntIterators into new IntIterators. The
+// composed iterators hold a reference to the iterators they are built from so
+// those should not be used independently while the composed iterator is in
+// use.
+
+// areaCounter is used by iterators that cannot know their Area without
+// iterating. The area is computed the first time it is requested and cached.
+type areaCounter struct {
+	area  int
+	known bool
+}
+
+// count iterates b to find its area. If b was part way through iteration, it
+// is returned to the same point.
+func (a *areaCounter) count(b BaseIntIterator) int {
+	if a.known {
+		return a.area
+	}
+	idx, done := b.Idx(), b.Done()
+	a.area = 0
+	for _, ok := b.Reset(); ok; _, ok = b.Next() {
+		a.area++
+	}
+	a.known = true
+
+	_, ok := b.Reset()
+	for ok && (done || b.Idx() < idx) {
+		_, ok = b.Next()
+	}
+	return a.area
+}
+
+type filterIterator struct {
+	it   IntIterator
+	fn   func(I) bool
+	cur  I
+	idx  int
+	done bool
+	areaCounter
+}
+
+// Filter returns an iterator over the points in it for which fn returns true.
+// Finding the Area requires a full pass over it, which is done the first time
+// Area is called.
+func Filter(it IntIterator, fn func(I) bool) IntIterator {
+	f := &filterIterator{
+		it: it,
+		fn: fn,
+	}
+	f.Reset()
+	return IterBaseWrapper{f}
+}
+
+// advance moves the underlying iterator forward until fn returns true.
+func (f *filterIterator) advance(pt I, ok bool) (I, bool) {
+	for ok && !f.fn(pt) {
+		pt, ok = f.it.Next()
+	}
+	if ok {
+		f.cur = pt
+	}
+	f.done = !ok
+	return f.cur, ok
+}
+
+// Done returns true if the iterator is done.
+func (f *filterIterator) Done() bool { return f.done }
+
+// I returns the current point of the iterator
+func (f *filterIterator) I() I { return f.cur }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (f *filterIterator) Next() (I, bool) {
+	if f.done {
+		return f.cur, false
+	}
+	pt, ok := f.advance(f.it.Next())
+	if ok {
+		f.idx++
+	}
+	return pt, ok
+}
+
+// Idx returns the index of the current point.
+func (f *filterIterator) Idx() int { return f.idx }
+
+// Area returns the numer of points the iterator will visit
+func (f *filterIterator) Area() int { return f.count(f) }
+
+// Reset the iterator.
+func (f *filterIterator) Reset() (I, bool) {
+	f.idx = 0
+	return f.advance(f.it.Reset())
+}
+
+func (f *filterIterator) copy() IntIterator {
+	return Filter(Copy(f.it), f.fn)
+}
+
+type mapIterator struct {
+	it IntIterator
+	fn func(I) I
+}
+
+// Map returns an iterator that visits fn(pt) for each point in it.
+func Map(it IntIterator, fn func(I) I) IntIterator {
+	return IterBaseWrapper{&mapIterator{
+		it: it,
+		fn: fn,
+	}}
+}
+
+// Offset returns an iterator that visits each point in it moved by d.
+func Offset(it IntIterator, d I) IntIterator {
+	return Map(it, func(pt I) I { return pt.Add(d) })
+}
+
+// Done returns true if the iterator is done.
+func (m *mapIterator) Done() bool { return m.it.Done() }
+
+// I returns the current point of the iterator
+func (m *mapIterator) I() I { return m.fn(m.it.I()) }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (m *mapIterator) Next() (I, bool) {
+	pt, ok := m.it.Next()
+	return m.fn(pt), ok
+}
+
+// Idx returns the index of the current point.
+func (m *mapIterator) Idx() int { return m.it.Idx() }
+
+// Area returns the numer of points the iterator will visit
+func (m *mapIterator) Area() int { return m.it.Area() }
+
+// Reset the iterator.
+func (m *mapIterator) Reset() (I, bool) {
+	pt, ok := m.it.Reset()
+	return m.fn(pt), ok
+}
+
+func (m *mapIterator) copy() IntIterator {
+	return Map(Copy(m.it), m.fn)
+}
+
+type concatIterator struct {
+	its  []IntIterator
+	i    int
+	cur  I
+	idx  int
+	done bool
+}
+
+// Concat returns an iterator that visits all the points in each iterator in
+// order.
+func Concat(its ...IntIterator) IntIterator {
+	c := &concatIterator{
+		its: its,
+	}
+	c.Reset()
+	return IterBaseWrapper{c}
+}
+
+// skipEmpty moves to the next iterator until one has a point.
+func (c *concatIterator) skipEmpty(pt I, ok bool) (I, bool) {
+	for !ok && c.i+1 < len(c.its) {
+		c.i++
+		pt, ok = c.its[c.i].Reset()
+	}
+	if ok {
+		c.cur = pt
+	}
+	c.done = !ok
+	return c.cur, ok
+}
+
+// Done returns true if the iterator is done.
+func (c *concatIterator) Done() bool { return c.done }
+
+// I returns the current point of the iterator
+func (c *concatIterator) I() I { return c.cur }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (c *concatIterator) Next() (I, bool) {
+	if c.done {
+		return c.cur, false
+	}
+	pt, ok := c.skipEmpty(c.its[c.i].Next())
+	if ok {
+		c.idx++
+	}
+	return pt, ok
+}
+
+// Idx returns the index of the current point.
+func (c *concatIterator) Idx() int { return c.idx }
+
+// Area returns the numer of points the iterator will visit
+func (c *concatIterator) Area() int {
+	var a int
+	for _, it := range c.its {
+		a += it.Area()
+	}
+	return a
+}
+
+// Reset the iterator.
+func (c *concatIterator) Reset() (I, bool) {
+	c.i = 0
+	c.idx = 0
+	if len(c.its) == 0 {
+		c.done = true
+		return c.cur, false
+	}
+	return c.skipEmpty(c.its[0].Reset())
+}
+
+func (c *concatIterator) copy() IntIterator {
+	its := make([]IntIterator, len(c.its))
+	for i, it := range c.its {
+		its[i] = Copy(it)
+	}
+	return Concat(its...)
+}
+
+type stepIterator struct {
+	it   IntIterator
+	n    int
+	idx  int
+	done bool
+}
+
+// Step returns an iterator that visits the first point of it and then every
+// nth point after that. If n is less than 1, every point is visited.
+func Step(it IntIterator, n int) IntIterator {
+	if n < 1 {
+		n = 1
+	}
+	s := &stepIterator{
+		it: it,
+		n:  n,
+	}
+	s.Reset()
+	return IterBaseWrapper{s}
+}
+
+// Done returns true if the iterator is done.
+func (s *stepIterator) Done() bool { return s.done }
+
+// I returns the current point of the iterator
+func (s *stepIterator) I() I { return s.it.I() }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (s *stepIterator) Next() (I, bool) {
+	if s.done {
+		return s.it.I(), false
+	}
+	var pt I
+	ok := true
+	for i := 0; ok && i < s.n; i++ {
+		pt, ok = s.it.Next()
+	}
+	if ok {
+		s.idx++
+	}
+	s.done = !ok
+	return pt, ok
+}
+
+// Idx returns the index of the current point.
+func (s *stepIterator) Idx() int { return s.idx }
+
+// Area returns the numer of points the iterator will visit
+func (s *stepIterator) Area() int {
+	return (s.it.Area() + s.n - 1) / s.n
+}
+
+// Reset the iterator.
+func (s *stepIterator) Reset() (I, bool) {
+	s.idx = 0
+	pt, ok := s.it.Reset()
+	s.done = !ok
+	return pt, ok
+}
+
+func (s *stepIterator) copy() IntIterator {
+	return Step(Copy(s.it), s.n)
+}
+
+type takeIterator struct {
+	it   IntIterator
+	n    int
+	idx  int
+	done bool
+}
+
+// Take returns an iterator that visits at most the first n points of it.
+func Take(it IntIterator, n int) IntIterator {
+	t := &takeIterator{
+		it: it,
+		n:  n,
+	}
+	t.Reset()
+	return IterBaseWrapper{t}
+}
+
+// Done returns true if the iterator is done.
+func (t *takeIterator) Done() bool { return t.done }
+
+// I returns the current point of the iterator
+func (t *takeIterator) I() I { return t.it.I() }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (t *takeIterator) Next() (I, bool) {
+	if t.done || t.idx+1 >= t.n {
+		t.done = true
+		return t.it.I(), false
+	}
+	pt, ok := t.it.Next()
+	if ok {
+		t.idx++
+	}
+	t.done = !ok
+	return pt, ok
+}
+
+// Idx returns the index of the current point.
+func (t *takeIterator) Idx() int { return t.idx }
+
+// Area returns the numer of points the iterator will visit
+func (t *takeIterator) Area() int {
+	if a := t.it.Area(); a < t.n {
+		return a
+	}
+	if t.n < 0 {
+		return 0
+	}
+	return t.n
+}
+
+// Reset the iterator.
+func (t *takeIterator) Reset() (I, bool) {
+	t.idx = 0
+	pt, ok := t.it.Reset()
+	t.done = !ok || t.n < 1
+	return pt, !t.done
+}
+
+func (t *takeIterator) copy() IntIterator {
+	return Take(Copy(t.it), t.n)
+}
+
+type skipIterator struct {
+	it IntIterator
+	n  int
+}
+
+// Skip returns an iterator that visits all the points in it after the first n
+// points.
+func Skip(it IntIterator, n int) IntIterator {
+	if n < 0 {
+		n = 0
+	}
+	s := &skipIterator{
+		it: it,
+		n:  n,
+	}
+	s.Reset()
+	return IterBaseWrapper{s}
+}
+
+// Done returns true if the iterator is done.
+func (s *skipIterator) Done() bool { return s.it.Done() }
+
+// I returns the current point of the iterator
+func (s *skipIterator) I() I { return s.it.I() }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (s *skipIterator) Next() (I, bool) { return s.it.Next() }
+
+// Idx returns the index of the current point.
+func (s *skipIterator) Idx() int { return s.it.Idx() - s.n }
+
+// Area returns the numer of points the iterator will visit
+func (s *skipIterator) Area() int {
+	if a := s.it.Area() - s.n; a > 0 {
+		return a
+	}
+	return 0
+}
+
+// Reset the iterator.
+func (s *skipIterator) Reset() (I, bool) {
+	pt, ok := s.it.Reset()
+	for i := 0; ok && i < s.n; i++ {
+		pt, ok = s.it.Next()
+	}
+	return pt, ok
+}
+
+func (s *skipIterator) copy() IntIterator {
+	return Skip(Copy(s.it), s.n)
+}
+
+type zipIterator struct {
+	a, b IntIterator
+	fn   func(a, b I) I
+	done bool
+}
+
+// Zip returns an iterator that advances a and b together and visits fn(a, b)
+// for each pair of points. It stops when either iterator is done.
+func Zip(a, b IntIterator, fn func(a, b I) I) IntIterator {
+	z := &zipIterator{
+		a:  a,
+		b:  b,
+		fn: fn,
+	}
+	z.Reset()
+	return IterBaseWrapper{z}
+}
+
+// Done returns true if the iterator is done.
+func (z *zipIterator) Done() bool { return z.done }
+
+// I returns the current point of the iterator
+func (z *zipIterator) I() I { return z.fn(z.a.I(), z.b.I()) }
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (z *zipIterator) Next() (I, bool) {
+	if z.done {
+		return z.I(), false
+	}
+	pa, oka := z.a.Next()
+	pb, okb := z.b.Next()
+	z.done = !oka || !okb
+	return z.fn(pa, pb), !z.done
+}
+
+// Idx returns the index of the current point.
+func (z *zipIterator) Idx() int { return z.a.Idx() }
+
+// Area returns the numer of points the iterator will visit
+func (z *zipIterator) Area() int {
+	a, b := z.a.Area(), z.b.Area()
+	if b < a {
+		return b
+	}
+	return a
+}
+
+// Reset the iterator.
+func (z *zipIterator) Reset() (I, bool) {
+	pa, oka := z.a.Reset()
+	pb, okb := z.b.Reset()
+	z.done = !oka || !okb
+	return z.fn(pa, pb), !z.done
+}
+
+func (z *zipIterator) copy() IntIterator {
+	return Zip(Copy(z.a), Copy(z.b), z.fn)
+}
+
+type distinctIterator struct {
+	filterIterator
+	seen map[I]bool
+}
+
+// Distinct returns an iterator over the points in it, skipping any point that
+// has already been visited. Finding the Area requires a full pass over it,
+// which is done the first time Area is called.
+func Distinct(it IntIterator) IntIterator {
+	d := &distinctIterator{}
+	d.it = it
+	d.fn = func(pt I) bool {
+		if d.seen[pt] {
+			return false
+		}
+		d.seen[pt] = true
+		return true
+	}
+	d.Reset()
+	return IterBaseWrapper{d}
+}
+
+// Area returns the numer of points the iterator will visit
+func (d *distinctIterator) Area() int { return d.count(d) }
+
+// Reset the iterator.
+func (d *distinctIterator) Reset() (I, bool) {
+	d.seen = make(map[I]bool)
+	return d.filterIterator.Reset()
+}
+
+func (d *distinctIterator) copy() IntIterator {
+	return Distinct(Copy(d.it))
+}
+
+// Intersect returns an iterator over the points in a that are also in b, in
+// the order they occur in a. The points in b are collected into a set when
+// Intersect is called.
+func Intersect(a, b IntIterator) IntIterator {
+	set := make(map[I]bool, b.Area())
+	b.Each(func(_ int, pt I) {
+		set[pt] = true
+	})
+	return Filter(a, func(pt I) bool { return set[pt] })
+}
+
+// Union returns an iterator over the points in a followed by the points in b,
+// visiting each distinct point only once.
+func Union(a, b IntIterator) IntIterator {
+	return Distinct(Concat(a, b))
+}
@@ -0,0 +1,98 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// checkIterator confirms that Area, Idx and Reset agree with the points that
+// the iterator visits.
+func checkIterator(t *testing.T, expected []I, it IntIterator) {
+	assert.Equal(t, len(expected), it.Area())
+	var got []I
+	for pt, ok := it.Reset(); ok; pt, ok = it.Next() {
+		assert.Equal(t, len(got), it.Idx())
+		assert.Equal(t, pt, it.I())
+		got = append(got, pt)
+	}
+	assert.True(t, it.Done())
+	if len(expected) == 0 {
+		assert.Len(t, got, 0)
+	} else {
+		assert.Equal(t, expected, got)
+	}
+	// a second pass after Reset must match
+	if len(got) > 0 {
+		assert.Equal(t, got, it.Slice())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(pt I) bool { return (pt.X+pt.Y)%2 == 0 }
+	checkIterator(t, []I{{0, 0}, {2, 0}, {1, 1}, {0, 2}, {2, 2}}, Filter(I{}.To(I{3, 3}), even))
+	checkIterator(t, nil, Filter(I{}.To(I{3, 3}), func(I) bool { return false }))
+	checkIterator(t, nil, Filter(I{}.To(I{}), even))
+
+	// Area called part way through iteration must not move the iterator
+	it := Filter(I{}.To(I{3, 3}), even)
+	it.Next()
+	it.Next()
+	assert.Equal(t, 5, it.Area())
+	assert.Equal(t, 2, it.Idx())
+	assert.Equal(t, I{1, 1}, it.I())
+	pt, ok := it.Next()
+	assert.True(t, ok)
+	assert.Equal(t, I{0, 2}, pt)
+}
+
+func TestMapOffset(t *testing.T) {
+	checkIterator(t, []I{{5, 5}, {6, 5}, {5, 6}, {6, 6}}, Offset(I{}.To(I{2, 2}), I{5, 5}))
+	swap := func(pt I) I { return I{pt.Y, pt.X} }
+	checkIterator(t, []I{{0, 0}, {0, 1}, {0, 2}}, Map(I{}.To(I{3, 1}), swap))
+}
+
+func TestConcat(t *testing.T) {
+	a := I{}.To(I{2, 1})
+	b := I{5, 5}.To(I{5, 5})
+	c := I{0, 3}.To(I{1, 5})
+	checkIterator(t, []I{{0, 0}, {1, 0}, {0, 3}, {0, 4}}, Concat(b, a, b, b, c, b))
+	checkIterator(t, nil, Concat())
+	checkIterator(t, nil, Concat(b, b))
+}
+
+func TestStepTakeSkip(t *testing.T) {
+	line := I{}.To(I{7, 1})
+	checkIterator(t, []I{{0, 0}, {3, 0}, {6, 0}}, Step(line, 3))
+	checkIterator(t, []I{{0, 0}, {2, 0}, {4, 0}, {6, 0}}, Step(line, 2))
+	checkIterator(t, line.Slice(), Step(line, 0))
+
+	checkIterator(t, []I{{0, 0}, {1, 0}, {2, 0}}, Take(line, 3))
+	checkIterator(t, line.Slice(), Take(line, 20))
+	checkIterator(t, nil, Take(line, 0))
+
+	checkIterator(t, []I{{5, 0}, {6, 0}}, Skip(line, 5))
+	checkIterator(t, nil, Skip(line, 7))
+	checkIterator(t, nil, Skip(line, 9))
+
+	checkIterator(t, []I{{2, 0}, {4, 0}}, Take(Skip(Step(line, 2), 1), 2))
+}
+
+func TestZip(t *testing.T) {
+	a := I{}.To(I{4, 1})
+	b := I{}.To(I{1, 3})
+	checkIterator(t, []I{{0, 0}, {1, 1}, {2, 2}}, Zip(a, b, I.Add))
+	checkIterator(t, nil, Zip(a, I{}.To(I{}), I.Add))
+}
+
+func TestSetCombinators(t *testing.T) {
+	a := I{}.To(I{3, 2})
+	b := I{1, 1}.To(I{4, 3})
+	checkIterator(t, []I{{1, 1}, {2, 1}}, Intersect(a, b))
+	checkIterator(t, nil, Intersect(a, I{5, 5}.To(I{6, 6})))
+
+	expected := append(a.Slice(), I{3, 1}, I{1, 2}, I{2, 2}, I{3, 2})
+	checkIterator(t, expected, Union(a, b))
+	checkIterator(t, a.Slice(), Union(a, a))
+
+	checkIterator(t, a.Slice(), Distinct(Concat(a, a, Take(a, 2))))
+}
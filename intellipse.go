@@ -0,0 +1,228 @@
+package vec2d
+
+import (
+	"math"
+	"sort"
+)
+
+// Circle returns an iterator over the outline of a circle centered at i with
+// radius r using the midpoint circle algorithm. The points proceed counter
+// clockwise starting from the +X axis.
+func (i I) Circle(r int) IntIterator {
+	return newPointsIterator(circleOutline(i, r))
+}
+
+// Disc returns an iterator over every point inside a circle centered at i with
+// radius r, including the points that Circle visits. The points are visited a
+// full row at a time.
+func (i I) Disc(r int) IntIterator {
+	return newSpanIterator(fillOutline(circleOutline(i, r)))
+}
+
+// Ellipse returns an iterator over the outline of an axis aligned ellipse
+// centered at i with the X radius r.X and the Y radius r.Y using the midpoint
+// ellipse algorithm. The points proceed counter clockwise starting from the +X
+// axis.
+func (i I) Ellipse(r I) IntIterator {
+	return newPointsIterator(ellipseOutline(i, r))
+}
+
+// FilledEllipse returns an iterator over every point inside an axis aligned
+// ellipse centered at i with the X radius r.X and the Y radius r.Y, including
+// the points that Ellipse visits. The points are visited a full row at a time.
+func (i I) FilledEllipse(r I) IntIterator {
+	return newSpanIterator(fillOutline(ellipseOutline(i, r)))
+}
+
+func circleOutline(c I, r int) []I {
+	if r < 0 {
+		r = -r
+	}
+	// midpoint circle algorithm generating the first octant
+	var octant []I
+	x, y, d := r, 0, 1-r
+	for x >= y {
+		octant = append(octant, I{x, y})
+		y++
+		if d < 0 {
+			d += 2*y + 1
+		} else {
+			x--
+			d += 2*(y-x) + 1
+		}
+	}
+
+	seen := make(map[I]bool, len(octant)*8)
+	var pts []I
+	add := func(pt I) {
+		if !seen[pt] {
+			seen[pt] = true
+			pts = append(pts, pt)
+		}
+	}
+	for _, o := range octant {
+		add(I{o.X, o.Y})
+		add(I{o.Y, o.X})
+		add(I{-o.Y, o.X})
+		add(I{-o.X, o.Y})
+		add(I{-o.X, -o.Y})
+		add(I{-o.Y, -o.X})
+		add(I{o.Y, -o.X})
+		add(I{o.X, -o.Y})
+	}
+	return sortOutline(c, pts)
+}
+
+func ellipseOutline(c I, r I) []I {
+	r = r.Abs()
+	a, b := r.X, r.Y
+	var quadrant []I
+	switch {
+	case a == 0:
+		for y := 0; y <= b; y++ {
+			quadrant = append(quadrant, I{0, y})
+		}
+	case b == 0:
+		for x := 0; x <= a; x++ {
+			quadrant = append(quadrant, I{x, 0})
+		}
+	default:
+		// midpoint ellipse algorithm generating the first quadrant
+		a2, b2 := float64(a*a), float64(b*b)
+		x, y := 0, b
+		dx, dy := 0.0, 2*a2*float64(y)
+		d := b2 - a2*float64(b) + a2/4
+		for dx < dy {
+			quadrant = append(quadrant, I{x, y})
+			x++
+			dx += 2 * b2
+			if d < 0 {
+				d += dx + b2
+			} else {
+				y--
+				dy -= 2 * a2
+				d += dx - dy + b2
+			}
+		}
+		fx, fy := float64(x)+0.5, float64(y-1)
+		d = b2*fx*fx + a2*fy*fy - a2*b2
+		for y >= 0 {
+			quadrant = append(quadrant, I{x, y})
+			y--
+			dy -= 2 * a2
+			if d > 0 {
+				d += a2 - dy
+			} else {
+				x++
+				dx += 2 * b2
+				d += dx - dy + a2
+			}
+		}
+	}
+
+	seen := make(map[I]bool, len(quadrant)*4)
+	var pts []I
+	for _, q := range quadrant {
+		for _, pt := range []I{q, {-q.X, q.Y}, {-q.X, -q.Y}, {q.X, -q.Y}} {
+			if !seen[pt] {
+				seen[pt] = true
+				pts = append(pts, pt)
+			}
+		}
+	}
+	return sortOutline(c, pts)
+}
+
+// sortOutline sorts offsets by angle, counter clockwise from the +X axis, and
+// adds c to each. Points at the same angle are sorted by distance from c.
+func sortOutline(c I, pts []I) []I {
+	half := func(pt I) int {
+		if pt.Y > 0 || (pt.Y == 0 && pt.X >= 0) {
+			return 0
+		}
+		return 1
+	}
+	sort.Slice(pts, func(i, j int) bool {
+		a, b := pts[i], pts[j]
+		if ha, hb := half(a), half(b); ha != hb {
+			return ha < hb
+		}
+		if cr := a.Cross(b); cr != 0 {
+			return cr > 0
+		}
+		return a.Dot(a) < b.Dot(b)
+	})
+	for i, pt := range pts {
+		pts[i] = pt.Add(c)
+	}
+	return pts
+}
+
+// fillOutline returns the spans that fill the area enclosed by the outline.
+// The outline must be convex along the X axis.
+func fillOutline(outline []I) []span {
+	rows := make(map[int]*span)
+	for _, pt := range outline {
+		s, ok := rows[pt.Y]
+		if !ok {
+			rows[pt.Y] = &span{Y: pt.Y, X0: pt.X, X1: pt.X + 1}
+			continue
+		}
+		if pt.X < s.X0 {
+			s.X0 = pt.X
+		}
+		if pt.X >= s.X1 {
+			s.X1 = pt.X + 1
+		}
+	}
+	spans := make([]span, 0, len(rows))
+	for _, s := range rows {
+		spans = append(spans, *s)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Y < spans[j].Y })
+	return spans
+}
+
+// maxOutlineSamples limits the number of points EllipseArc.Outline samples on
+// the arc. Larger arcs are still connected, the gaps between samples are
+// filled with lines.
+const maxOutlineSamples = 1 << 20
+
+// Outline returns an iterator over the grid points along the arc. Each point
+// on the arc is rounded to the nearest grid point and consecutive points are
+// always adjacent, including diagonally. If the arc is a full rotation, the
+// first point is not repeated at the end. If any value defining the arc is NaN
+// or infinite, the iterator is empty.
+func (e EllipseArc) Outline() IntIterator {
+	for _, f := range []float64{e.c.X, e.c.Y, e.sMa, e.sma, e.a, e.Start, e.Length} {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return newPointsIterator(nil)
+		}
+	}
+	r := math.Max(math.Abs(e.sMa), math.Abs(e.sma))
+	// sample often enough that each step moves less than half a grid cell
+	n := int(math.Min(math.Ceil(math.Abs(e.Length)*r*2), maxOutlineSamples)) + 1
+	round := func(f F) I {
+		return I{int(math.Round(f.X)), int(math.Round(f.Y))}
+	}
+
+	prev := round(e.F(0))
+	pts := []I{prev}
+	for s := 1; s <= n; s++ {
+		pt := round(e.F(float64(s) / float64(n)))
+		if pt == prev {
+			continue
+		}
+		if d := pt.Subtract(prev).Abs(); d.X > 1 || d.Y > 1 {
+			line := prev.LineTo(pt).Slice()
+			pts = append(pts, line[1:]...)
+		} else {
+			pts = append(pts, pt)
+		}
+		prev = pt
+	}
+	if math.Abs(e.Length) >= Tau && len(pts) > 1 && pts[len(pts)-1] == pts[0] {
+		pts = pts[:len(pts)-1]
+	}
+	return newPointsIterator(pts)
+}
@@ -0,0 +1,131 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func assertClosedOutline(t *testing.T, pts []I) {
+	seen := make(map[I]bool)
+	for i, pt := range pts {
+		assert.False(t, seen[pt], "duplicate %v", pt)
+		seen[pt] = true
+		d := pt.Subtract(pts[(i+1)%len(pts)]).Abs()
+		assert.True(t, d.X <= 1 && d.Y <= 1, "gap from %v", pt)
+	}
+}
+
+func TestCircleOutline(t *testing.T) {
+	assert.Equal(t, []I{{3, 4}}, I{3, 4}.Circle(0).Slice())
+	assert.Equal(t, []I{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}, I{}.Circle(1).Slice())
+
+	c := I{10, -3}
+	for r := 2; r < 30; r++ {
+		it := c.Circle(r)
+		pts := it.Slice()
+		assert.Equal(t, it.Area(), len(pts))
+		assert.Equal(t, I{10 + r, -3}, pts[0])
+		assertClosedOutline(t, pts)
+		for _, pt := range pts {
+			assert.InDelta(t, float64(r), pt.Distance(c), 0.5, pt)
+		}
+	}
+}
+
+func TestDisc(t *testing.T) {
+	c := I{-2, 5}
+	for r := 0; r < 20; r++ {
+		disc := make(map[I]bool)
+		var prev I
+		c.Disc(r).Each(func(idx int, pt I) {
+			if idx > 0 {
+				assert.True(t, pt.Y > prev.Y || (pt.Y == prev.Y && pt.X == prev.X+1))
+			}
+			prev = pt
+			disc[pt] = true
+		})
+		assert.Equal(t, c.Disc(r).Area(), len(disc))
+		c.Circle(r).Each(func(_ int, pt I) {
+			assert.True(t, disc[pt], pt)
+		})
+		for pt := range disc {
+			assert.True(t, pt.Distance(c) < float64(r)+0.5)
+		}
+		assert.InDelta(t, math.Pi*float64(r*r), float64(len(disc)), float64(4*r+1))
+	}
+}
+
+func TestEllipseOutline(t *testing.T) {
+	assert.ElementsMatch(t, []I{{2, 0}, {1, 0}, {0, 0}, {-1, 0}, {-2, 0}}, I{}.Ellipse(I{2, 0}).Slice())
+	assert.Equal(t, 5, I{}.Ellipse(I{2, 0}).Area())
+	assert.Equal(t, 7, I{}.Ellipse(I{0, 3}).Area())
+
+	c := I{1, 1}
+	for _, r := range []I{{5, 3}, {3, 5}, {10, 2}, {7, 7}, {20, 13}} {
+		pts := c.Ellipse(r).Slice()
+		assert.Equal(t, c.Add(I{r.X, 0}), pts[0])
+		assertClosedOutline(t, pts)
+		for _, pt := range pts {
+			d := pt.Subtract(c).F()
+			// the point should be within half a cell of the true ellipse
+			e := math.Sqrt(d.X*d.X/float64(r.X*r.X) + d.Y*d.Y/float64(r.Y*r.Y))
+			assert.InDelta(t, 1, e, 0.5/math.Min(float64(r.X), float64(r.Y))+0.01, pt)
+		}
+
+		filled := make(map[I]bool)
+		c.FilledEllipse(r).Each(func(_ int, pt I) { filled[pt] = true })
+		assert.Equal(t, c.FilledEllipse(r).Area(), len(filled))
+		for _, pt := range pts {
+			assert.True(t, filled[pt])
+		}
+	}
+}
+
+func TestEllipseArcOutline(t *testing.T) {
+	c := NewCircle(F{5, 5}, 10)
+	pts := c.Arc().Outline().Slice()
+	assert.Equal(t, I{15, 5}, pts[0])
+	assertClosedOutline(t, pts)
+	for _, pt := range pts {
+		assert.InDelta(t, 10, pt.F().Distance(F{5, 5}), 0.75)
+	}
+
+	e := NewEllipseArc(F{0, 0}, F{8, 6}, 3)
+	e.Length = Pi
+	pts = e.Outline().Slice()
+	assert.Equal(t, I{int(math.Round(e.F(0).X)), int(math.Round(e.F(0).Y))}, pts[0])
+	last := e.F(1)
+	assert.Equal(t, I{int(math.Round(last.X)), int(math.Round(last.Y))}, pts[len(pts)-1])
+	for i := 1; i < len(pts); i++ {
+		d := pts[i].Subtract(pts[i-1]).Abs()
+		assert.True(t, d.X <= 1 && d.Y <= 1 && d != I{})
+	}
+}
+
+func TestEllipseArcOutlineLimits(t *testing.T) {
+	inf := math.Inf(1)
+	for _, e := range []EllipseArc{
+		NewCircle(F{0, 0}, inf).Arc(),
+		NewCircle(F{0, 0}, -inf).Arc(),
+		NewCircle(F{0, 0}, math.NaN()).Arc(),
+		NewCircle(F{inf, 0}, 5).Arc(),
+		func() EllipseArc {
+			e := NewCircle(F{0, 0}, 5).Arc()
+			e.Length = inf
+			return e
+		}(),
+	} {
+		assert.Equal(t, 0, e.Outline().Area())
+	}
+
+	// more samples than the limit are needed, the gaps are filled
+	e := NewCircle(F{0, 0}, 1e6).Arc()
+	e.Length = 1
+	pts := e.Outline().Slice()
+	assert.Equal(t, I{1000000, 0}, pts[0])
+	for i := 1; i < len(pts); i++ {
+		d := pts[i].Subtract(pts[i-1]).Abs()
+		assert.True(t, d.X <= 1 && d.Y <= 1 && d != I{})
+	}
+}
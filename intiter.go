@@ -1,5 +1,9 @@
 package vec2d
 
+import (
+	"iter"
+)
+
 // BaseIntIterator provides the base methods that are needed to provide a full
 // IntIterator.
 type BaseIntIterator interface {
@@ -21,6 +25,29 @@ type IntIterator interface {
 	Until(fn func(int, I) bool) bool
 }
 
+// copier is fulfilled by a BaseIntIterator that can create an independent
+// iterator over the same points.
+type copier interface {
+	copy() IntIterator
+}
+
+// Copy returns an iterator over the same points as it that does not share any
+// iteration state with it, so the two can be used in different Go routines.
+// The copy is reset to the first point. Functions passed to combinators such as
+// Filter and Map are shared by the copy. If it does not support copying, its
+// points are collected into a slice, which resets it.
+func Copy(it IntIterator) IntIterator {
+	if w, ok := it.(IterBaseWrapper); ok {
+		if c, ok := w.BaseIntIterator.(copier); ok {
+			return c.copy()
+		}
+	}
+	if c, ok := it.(copier); ok {
+		return c.copy()
+	}
+	return newPointsIterator(it.Slice())
+}
+
 // xIterator is return from .To or .FromOrigin, it iterators by incrementing X
 // first.
 type xIterator struct {
@@ -124,6 +151,12 @@ func (xi *xIterator) Reset() (I, bool) {
 	return xi.cur, !xi.done
 }
 
+func (xi *xIterator) copy() IntIterator {
+	c := *xi
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
+
 // IterBaseWrapper takes a type that fulfills BaseIntIterator and wraps it to
 // fulfill IntIterator.
 type IterBaseWrapper struct {
@@ -164,9 +197,31 @@ func (base IterBaseWrapper) Until(fn func(int, I) bool) bool {
 	return false
 }
 
+// All returns a sequence of the index and point of each point in the iterator
+// for use with range. The iterator is reset when the sequence starts.
+//
+//	for idx, pt := range vec2d.All(a.To(b)) {
+//	  fmt.Println(idx, pt)
+//	}
+func All(it IntIterator) iter.Seq2[int, I] {
+	return func(yield func(int, I) bool) {
+		for pt, ok := it.Reset(); ok; pt, ok = it.Next() {
+			if !yield(it.Idx(), pt) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of the index and point of each point in the iterator
+// for use with range. See All.
+func (base IterBaseWrapper) All() iter.Seq2[int, I] {
+	return All(base)
+}
+
 // Chan runs a go routine that will return the points of the iterator. When all
 // the points are consumed the channel is closed. Failing to consume all the
-// points will cause a Go routine leak.
+// points will cause a Go routine leak. ChanContext avoids this.
 func (base IterBaseWrapper) Chan() <-chan I {
 	c := make(chan I)
 	go func() {
@@ -177,3 +232,148 @@ func (base IterBaseWrapper) Chan() <-chan I {
 	}()
 	return c
 }
+
+// pointsIterator iterates over a precomputed list of points. It is used when
+// the points are generated in an order that is different from the order they
+// are visited.
+type pointsIterator struct {
+	pts  []I
+	idx  int
+	done bool
+}
+
+func newPointsIterator(pts []I) IntIterator {
+	pi := &pointsIterator{pts: pts}
+	pi.Reset()
+	return IterBaseWrapper{pi}
+}
+
+// Done returns true if the iterator is done.
+func (pi *pointsIterator) Done() bool {
+	return pi.done
+}
+
+// I returns the current point of the iterator
+func (pi *pointsIterator) I() I {
+	if pi.idx < len(pi.pts) {
+		return pi.pts[pi.idx]
+	}
+	if len(pi.pts) > 0 {
+		return pi.pts[len(pi.pts)-1]
+	}
+	return I{}
+}
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (pi *pointsIterator) Next() (I, bool) {
+	if !pi.done {
+		pi.idx++
+		pi.done = pi.idx >= len(pi.pts)
+	}
+	return pi.I(), !pi.done
+}
+
+// Idx returns the index of the current point.
+func (pi *pointsIterator) Idx() int { return pi.idx }
+
+// Area returns the numer of points the iterator will visit
+func (pi *pointsIterator) Area() int { return len(pi.pts) }
+
+// Reset the iterator.
+func (pi *pointsIterator) Reset() (I, bool) {
+	pi.idx = 0
+	pi.done = len(pi.pts) == 0
+	return pi.I(), !pi.done
+}
+
+func (pi *pointsIterator) copy() IntIterator {
+	c := *pi
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
+
+// span is a horizontal run of points from X0 (inclusive) to X1 (exclusive).
+type span struct {
+	Y, X0, X1 int
+}
+
+// spanIterator iterates over a list of spans, visiting each point in a span
+// before moving to the next span. This allows filled shapes to be iterated
+// without generating every point ahead of time.
+type spanIterator struct {
+	spans []span
+	area  int
+	si    int
+	cur   I
+	idx   int
+	done  bool
+}
+
+// newSpanIterator removes any empty spans and returns an iterator over the
+// rest.
+func newSpanIterator(spans []span) IntIterator {
+	s := &spanIterator{
+		spans: spans[:0],
+	}
+	for _, sp := range spans {
+		if sp.X1 > sp.X0 {
+			s.spans = append(s.spans, sp)
+			s.area += sp.X1 - sp.X0
+		}
+	}
+	s.Reset()
+	return IterBaseWrapper{s}
+}
+
+// Done returns true if the iterator is done.
+func (s *spanIterator) Done() bool {
+	return s.done
+}
+
+// I returns the current point of the iterator
+func (s *spanIterator) I() I {
+	return s.cur
+}
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (s *spanIterator) Next() (I, bool) {
+	if s.done {
+		return s.cur, false
+	}
+	s.idx++
+	s.cur.X++
+	if s.cur.X >= s.spans[s.si].X1 {
+		s.si++
+		if s.si >= len(s.spans) {
+			s.done = true
+			return s.cur, false
+		}
+		s.cur = I{s.spans[s.si].X0, s.spans[s.si].Y}
+	}
+	return s.cur, true
+}
+
+// Idx returns the index of the current point.
+func (s *spanIterator) Idx() int { return s.idx }
+
+// Area returns the numer of points the iterator will visit
+func (s *spanIterator) Area() int { return s.area }
+
+// Reset the iterator.
+func (s *spanIterator) Reset() (I, bool) {
+	s.si = 0
+	s.idx = 0
+	s.done = len(s.spans) == 0
+	if !s.done {
+		s.cur = I{s.spans[0].X0, s.spans[0].Y}
+	}
+	return s.cur, !s.done
+}
+
+func (s *spanIterator) copy() IntIterator {
+	c := *s
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
@@ -0,0 +1,211 @@
+package vec2d
+
+// lineIterator is returned from I.LineTo. It uses Bresenham's line algorithm.
+type lineIterator struct {
+	from, to, cur I
+	d, s          I
+	err           int
+	idx           int
+	done          bool
+}
+
+// LineTo returns an iterator over the points on the line from i to i2 using
+// Bresenham's line algorithm. Unlike To, both i and i2 are included. Exactly
+// one point is visited for each step along the longer axis.
+func (i I) LineTo(i2 I) IntIterator {
+	d := i2.Subtract(i)
+	li := &lineIterator{
+		from: i,
+		to:   i2,
+		d:    d.Abs(),
+		s:    I{signInt(d.X), signInt(d.Y)},
+	}
+	li.Reset()
+	return IterBaseWrapper{li}
+}
+
+func signInt(i int) int {
+	if i < 0 {
+		return -1
+	}
+	return 1
+}
+
+// Done returns true if the iterator is done.
+func (li *lineIterator) Done() bool {
+	return li.done
+}
+
+// I returns the current point of the iterator
+func (li *lineIterator) I() I {
+	return li.cur
+}
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (li *lineIterator) Next() (I, bool) {
+	if li.done {
+		return li.cur, false
+	}
+	if li.cur == li.to {
+		li.done = true
+		return li.cur, false
+	}
+	// err tracks the error of the next point scaled by 2*d.X*d.Y, it is
+	// initialized to d.X - d.Y
+	e2 := 2 * li.err
+	if e2 >= -li.d.Y {
+		li.err -= li.d.Y
+		li.cur.X += li.s.X
+	}
+	if e2 <= li.d.X {
+		li.err += li.d.X
+		li.cur.Y += li.s.Y
+	}
+	li.idx++
+	return li.cur, true
+}
+
+// Idx returns the index of the current point.
+func (li *lineIterator) Idx() int { return li.idx }
+
+// Area returns the numer of points the iterator will visit
+func (li *lineIterator) Area() int {
+	if li.d.X > li.d.Y {
+		return li.d.X + 1
+	}
+	return li.d.Y + 1
+}
+
+// Reset the iterator.
+func (li *lineIterator) Reset() (I, bool) {
+	li.cur = li.from
+	li.err = li.d.X - li.d.Y
+	li.idx = 0
+	li.done = false
+	return li.cur, true
+}
+
+func (li *lineIterator) copy() IntIterator {
+	c := *li
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
+
+// supercoverIterator is returned from I.Supercover. It walks the grid one
+// axis at a time so that every cell the line touches is visited.
+type supercoverIterator struct {
+	from, base, cur I
+	n, s            I
+	ix, iy          int
+	corner          int
+	idx             int
+	done            bool
+}
+
+// Supercover returns an iterator over every cell that the line from the center
+// of cell i to the center of cell i2 passes through. Both i and i2 are
+// included. If the line passes exactly through the corner of a cell, both of
+// the cells that share that corner are visited before the diagonal cell.
+func (i I) Supercover(i2 I) IntIterator {
+	d := i2.Subtract(i)
+	si := &supercoverIterator{
+		from: i,
+		n:    d.Abs(),
+		s:    I{signInt(d.X), signInt(d.Y)},
+	}
+	si.Reset()
+	return IterBaseWrapper{si}
+}
+
+// Done returns true if the iterator is done.
+func (si *supercoverIterator) Done() bool {
+	return si.done
+}
+
+// I returns the current point of the iterator
+func (si *supercoverIterator) I() I {
+	return si.cur
+}
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (si *supercoverIterator) Next() (I, bool) {
+	if si.done {
+		return si.cur, false
+	}
+	switch si.corner {
+	case 1:
+		si.corner = 2
+		si.cur = I{si.base.X, si.base.Y + si.s.Y}
+	case 2:
+		si.corner = 0
+		si.base = si.base.Add(si.s)
+		si.cur = si.base
+	default:
+		if si.ix >= si.n.X && si.iy >= si.n.Y {
+			si.done = true
+			return si.cur, false
+		}
+		// Compare where the line crosses the next vertical and horizontal grid
+		// lines, scaled by 2*n.X*n.Y to stay in integers.
+		decision := (1+2*si.ix)*si.n.Y - (1+2*si.iy)*si.n.X
+		switch {
+		case decision == 0:
+			si.ix++
+			si.iy++
+			si.corner = 1
+			si.cur = I{si.base.X + si.s.X, si.base.Y}
+		case decision < 0:
+			si.ix++
+			si.base.X += si.s.X
+			si.cur = si.base
+		default:
+			si.iy++
+			si.base.Y += si.s.Y
+			si.cur = si.base
+		}
+	}
+	si.idx++
+	return si.cur, true
+}
+
+// Idx returns the index of the current point.
+func (si *supercoverIterator) Idx() int { return si.idx }
+
+// Area returns the numer of points the iterator will visit
+func (si *supercoverIterator) Area() int {
+	// Each step along either axis visits one cell. Each time the line passes
+	// through a corner one extra cell is visited. That happens gcd(n.X, n.Y)
+	// times when both reduced deltas are odd.
+	a := si.n.X + si.n.Y + 1
+	g := gcd(si.n.X, si.n.Y)
+	if g > 0 && (si.n.X/g)&1 == 1 && (si.n.Y/g)&1 == 1 {
+		a += g
+	}
+	return a
+}
+
+// Reset the iterator.
+func (si *supercoverIterator) Reset() (I, bool) {
+	si.base = si.from
+	si.cur = si.from
+	si.ix, si.iy = 0, 0
+	si.corner = 0
+	si.idx = 0
+	si.done = false
+	return si.cur, true
+}
+
+func (si *supercoverIterator) copy() IntIterator {
+	c := *si
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
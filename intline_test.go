@@ -0,0 +1,78 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLineToInt(t *testing.T) {
+	tt := []struct {
+		from, to I
+		expected []I
+	}{
+		{I{0, 0}, I{0, 0}, []I{{0, 0}}},
+		{I{0, 0}, I{3, 0}, []I{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{I{0, 0}, I{5, 2}, []I{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}}},
+		{I{5, 2}, I{0, 0}, []I{{5, 2}, {4, 2}, {3, 1}, {2, 1}, {1, 0}, {0, 0}}},
+		{I{1, 1}, I{-1, 4}, []I{{1, 1}, {0, 2}, {0, 3}, {-1, 4}}},
+		{I{0, 0}, I{-2, -2}, []I{{0, 0}, {-1, -1}, {-2, -2}}},
+	}
+	for _, tc := range tt {
+		it := tc.from.LineTo(tc.to)
+		assert.Equal(t, len(tc.expected), it.Area())
+		assert.Equal(t, tc.expected, it.Slice())
+	}
+}
+
+func TestLineToIntContinuous(t *testing.T) {
+	for it, to, ok := (I{-5, -5}).To(I{6, 6}).Start(); ok; to, ok = it.Next() {
+		li := I{0, 0}.LineTo(to)
+		pts := li.Slice()
+		assert.Equal(t, I{0, 0}, pts[0])
+		assert.Equal(t, to, pts[len(pts)-1])
+		for i := 1; i < len(pts); i++ {
+			d := pts[i].Subtract(pts[i-1]).Abs()
+			assert.True(t, d.X <= 1 && d.Y <= 1 && d != I{}, to)
+		}
+	}
+}
+
+func TestSupercover(t *testing.T) {
+	tt := []struct {
+		from, to I
+		expected []I
+	}{
+		{I{0, 0}, I{0, 0}, []I{{0, 0}}},
+		{I{0, 0}, I{2, 0}, []I{{0, 0}, {1, 0}, {2, 0}}},
+		{I{0, 0}, I{2, 1}, []I{{0, 0}, {1, 0}, {1, 1}, {2, 1}}},
+		{I{0, 0}, I{2, 2}, []I{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}, {2, 2}}},
+		{I{0, 0}, I{-3, 1}, []I{{0, 0}, {-1, 0}, {-2, 0}, {-1, 1}, {-2, 1}, {-3, 1}}},
+		{I{0, 0}, I{-4, 1}, []I{{0, 0}, {-1, 0}, {-2, 0}, {-2, 1}, {-3, 1}, {-4, 1}}},
+	}
+	for _, tc := range tt {
+		it := tc.from.Supercover(tc.to)
+		assert.Equal(t, len(tc.expected), it.Area(), tc.to)
+		assert.Equal(t, tc.expected, it.Slice(), tc.to)
+	}
+}
+
+func TestSupercoverArea(t *testing.T) {
+	for it, to, ok := (I{-7, -7}).To(I{8, 8}).Start(); ok; to, ok = it.Next() {
+		sc := I{0, 0}.Supercover(to)
+		var count int
+		seen := make(map[I]bool)
+		sc.Each(func(idx int, pt I) {
+			assert.Equal(t, count, idx)
+			count++
+			seen[pt] = true
+		})
+		assert.Equal(t, sc.Area(), count, to)
+		assert.Equal(t, count, len(seen), to)
+		assert.True(t, seen[to])
+
+		// every Bresenham point is also touched by the supercover
+		I{0, 0}.LineTo(to).Each(func(_ int, pt I) {
+			assert.True(t, seen[pt], pt)
+		})
+	}
+}
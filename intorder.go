@@ -0,0 +1,355 @@
+package vec2d
+
+// rectOrder defines the order that a rectIterator visits the points in a
+// rectangle. The offsets it returns are relative to the starting corner of the
+// rectangle and must lie in the range from the origin to size.
+type rectOrder interface {
+	// reset returns the offset of the first point.
+	reset(size I) I
+	// next returns the offset of the point at idx. It is always called with
+	// sequential values of idx and idx is always less than the area.
+	next(size I, idx int) I
+	// copy returns a rectOrder that does not share state with this one.
+	copy() rectOrder
+}
+
+// rectIterator visits every point in the rectangle between from (inclusive)
+// and to (exclusive), the same rectangle used by To, in the order defined by a
+// rectOrder.
+type rectIterator struct {
+	from, dir, size I
+	order           rectOrder
+	cur             I
+	idx             int
+	done            bool
+}
+
+func newRectIterator(from, to I, order rectOrder) IntIterator {
+	d := to.Subtract(from)
+	r := &rectIterator{
+		from:  from,
+		dir:   I{signInt(d.X), signInt(d.Y)},
+		size:  d.Abs(),
+		order: order,
+	}
+	r.Reset()
+	return IterBaseWrapper{r}
+}
+
+func (r *rectIterator) point(offset I) I {
+	return r.from.Add(offset.Multiply(r.dir))
+}
+
+// Done returns true if the iterator is done.
+func (r *rectIterator) Done() bool {
+	return r.done
+}
+
+// I returns the current point of the iterator
+func (r *rectIterator) I() I {
+	return r.cur
+}
+
+// Next fulfills the IntIterator interface. It returns the next point and if
+// iteration is done.
+func (r *rectIterator) Next() (I, bool) {
+	if r.done {
+		return r.cur, false
+	}
+	r.idx++
+	if r.idx >= r.Area() {
+		r.done = true
+		return r.cur, false
+	}
+	r.cur = r.point(r.order.next(r.size, r.idx))
+	return r.cur, true
+}
+
+// Idx returns the index of the current point.
+func (r *rectIterator) Idx() int { return r.idx }
+
+// Area returns the numer of points the iterator will visit
+func (r *rectIterator) Area() int { return r.size.Area() }
+
+// Reset the iterator.
+func (r *rectIterator) Reset() (I, bool) {
+	r.idx = 0
+	r.done = r.Area() == 0
+	r.cur = r.from
+	if !r.done {
+		r.cur = r.point(r.order.reset(r.size))
+	}
+	return r.cur, !r.done
+}
+
+func (r *rectIterator) copy() IntIterator {
+	c := *r
+	c.order = r.order.copy()
+	c.Reset()
+	return IterBaseWrapper{&c}
+}
+
+// ToColumns iterates over the same points as To, but moves down a full column
+// before moving to the next column.
+func (i I) ToColumns(i2 I) IntIterator {
+	return newRectIterator(i, i2, columnOrder{})
+}
+
+type columnOrder struct{}
+
+func (columnOrder) reset(size I) I { return I{} }
+
+func (o columnOrder) copy() rectOrder { return o }
+
+func (columnOrder) next(size I, idx int) I {
+	return I{idx / size.Y, idx % size.Y}
+}
+
+// ToSerpentine iterates over the same points as To, moving across the first
+// row, then back across the second row in the opposite direction and so on
+// (boustrophedon order). Consecutive points are always adjacent.
+func (i I) ToSerpentine(i2 I) IntIterator {
+	return newRectIterator(i, i2, serpentineOrder{})
+}
+
+type serpentineOrder struct{}
+
+func (serpentineOrder) reset(size I) I { return I{} }
+
+func (o serpentineOrder) copy() rectOrder { return o }
+
+func (serpentineOrder) next(size I, idx int) I {
+	pt := I{idx % size.X, idx / size.X}
+	if pt.Y&1 == 1 {
+		pt.X = size.X - 1 - pt.X
+	}
+	return pt
+}
+
+// ToSpiral iterates over the same points as To, starting from the center of
+// the rectangle and spiraling outward counter clockwise. When the rectangle is
+// not square, the parts of the spiral that fall outside it are skipped. Each
+// leg of the spiral is clipped to the rectangle, so a long thin rectangle does
+// not walk the whole enclosing square.
+func (i I) ToSpiral(i2 I) IntIterator {
+	return newRectIterator(i, i2, &spiralOrder{})
+}
+
+var spiralDirs = [4]I{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+
+// spiralOrder walks a square spiral with legs of length 1, 1, 2, 2, 3, 3...
+type spiralOrder struct {
+	pos          I
+	dir          int
+	legLen, step int
+}
+
+func (s *spiralOrder) reset(size I) I {
+	s.pos = I{(size.X - 1) / 2, (size.Y - 1) / 2}
+	s.dir = 0
+	s.legLen = 1
+	s.step = 0
+	return s.pos
+}
+
+func (s *spiralOrder) next(size I, idx int) I {
+	for {
+		d := spiralDirs[s.dir]
+		remaining := s.legLen - s.step
+		// clip the rest of the leg to the rectangle so the parts of the
+		// spiral outside it are skipped in one move
+		lo, hi := 1, remaining
+		lo, hi = clipLeg(lo, hi, s.pos.X, d.X, size.X)
+		lo, hi = clipLeg(lo, hi, s.pos.Y, d.Y, size.Y)
+		k := remaining
+		if lo <= hi {
+			k = lo
+		}
+		s.pos = s.pos.Add(d.Multiply(I{k, k}))
+		s.step += k
+		if s.step == s.legLen {
+			s.step = 0
+			s.dir = (s.dir + 1) & 3
+			if s.dir&1 == 0 {
+				s.legLen++
+			}
+		}
+		if lo <= hi {
+			return s.pos
+		}
+	}
+}
+
+// clipLeg limits the range of steps [lo, hi] so that p+k*d is in [0, size)
+// for every k in the range. If no steps are in range, lo will be greater than
+// hi.
+func clipLeg(lo, hi, p, d, size int) (int, int) {
+	switch d {
+	case 0:
+		if p < 0 || p >= size {
+			return 1, 0
+		}
+	case 1:
+		lo, hi = max(lo, -p), min(hi, size-1-p)
+	case -1:
+		lo, hi = max(lo, p-size+1), min(hi, p)
+	}
+	return lo, hi
+}
+
+func (s *spiralOrder) copy() rectOrder {
+	c := *s
+	return &c
+}
+
+// ToMorton iterates over the same points as To in Z-order, also called Morton
+// order. Points that are close together in the iteration are close together
+// spatially. Codes outside the rectangle are skipped in one step, so each
+// point costs O(1) amortized plus O(log) when the curve leaves the rectangle.
+func (i I) ToMorton(i2 I) IntIterator {
+	return newRectIterator(i, i2, &mortonOrder{})
+}
+
+type mortonOrder struct {
+	code uint64
+}
+
+func (m *mortonOrder) reset(size I) I {
+	m.code = 0
+	return I{}
+}
+
+func (m *mortonOrder) next(size I, idx int) I {
+	m.code++
+	if pt := FromMorton(m.code); !pt.In(I{}, size) {
+		m.code = bigMin(m.code, 0, I{size.X - 1, size.Y - 1}.Morton())
+	}
+	return FromMorton(m.code)
+}
+
+// bigMin returns the smallest Morton code greater than code that is in the
+// rectangle between the codes zmin and zmax, skipping the codes outside it.
+// This is the BIGMIN calculation from Tropf and Herzog.
+func bigMin(code, zmin, zmax uint64) uint64 {
+	var out uint64
+	for bit := 63; bit >= 0; bit-- {
+		b := uint64(1) << bit
+		// the lower bits of the same axis as bit
+		lower := (uint64(0x5555555555555555) << (bit & 1)) & (b - 1)
+		switch {
+		case code&b == 0 && zmin&b == 0 && zmax&b != 0:
+			out = zmin&^lower | b
+			zmax = (zmax | lower) &^ b
+		case code&b == 0 && zmin&b != 0:
+			return zmin
+		case code&b != 0 && zmax&b == 0:
+			return out
+		case code&b != 0 && zmin&b == 0 && zmax&b != 0:
+			zmin = zmin&^lower | b
+		}
+	}
+	return out
+}
+
+func (m *mortonOrder) copy() rectOrder {
+	c := *m
+	return &c
+}
+
+// ToHilbert iterates over the same points as To following a Hilbert curve.
+// Consecutive points are always adjacent when the rectangle is a square with
+// sides that are a power of 2. For other rectangles, the parts of the curve
+// that fall outside the rectangle are skipped a whole quadrant at a time, so a
+// long thin rectangle does not walk the whole enclosing square.
+func (i I) ToHilbert(i2 I) IntIterator {
+	return newRectIterator(i, i2, &hilbertOrder{})
+}
+
+type hilbertOrder struct {
+	n, d int
+}
+
+func (h *hilbertOrder) reset(size I) I {
+	h.n = 1
+	for h.n < size.X || h.n < size.Y {
+		h.n <<= 1
+	}
+	h.d = 0
+	return I{}
+}
+
+func (h *hilbertOrder) next(size I, idx int) I {
+	for {
+		h.d++
+		pt := hilbertPoint(h.n, h.d)
+		if pt.In(I{}, size) {
+			return pt
+		}
+		// each aligned block of s*s distances fills an s by s square, so skip
+		// the largest block starting at d that is outside the rectangle
+		s := 1
+		for s < h.n && h.d&(4*s*s-1) == 0 &&
+			(pt.X&^(2*s-1) >= size.X || pt.Y&^(2*s-1) >= size.Y) {
+			s <<= 1
+		}
+		h.d += s*s - 1
+	}
+}
+
+func (h *hilbertOrder) copy() rectOrder {
+	c := *h
+	return &c
+}
+
+// hilbertPoint returns the point at distance d along a Hilbert curve filling
+// an n by n square. The value n must be a power of 2.
+func hilbertPoint(n, d int) I {
+	var pt I
+	for s := 1; s < n; s <<= 1 {
+		rx := 1 & (d >> 1)
+		ry := 1 & (d ^ rx)
+		if ry == 0 {
+			if rx == 1 {
+				pt = I{s - 1 - pt.X, s - 1 - pt.Y}
+			}
+			pt.X, pt.Y = pt.Y, pt.X
+		}
+		pt = pt.Add(I{s * rx, s * ry})
+		d >>= 2
+	}
+	return pt
+}
+
+// Morton returns the Z-order (Morton) code of i by interleaving the bits of X
+// and Y, with X in the lowest bit. Only the lower 32 bits of X and Y are used,
+// so only values in the range [0, 2^32) can be recovered with FromMorton.
+func (i I) Morton() uint64 {
+	return spreadBits(uint32(i.X)) | spreadBits(uint32(i.Y))<<1
+}
+
+// FromMorton returns the point with the given Z-order (Morton) code.
+func FromMorton(m uint64) I {
+	return I{int(compactBits(m)), int(compactBits(m >> 1))}
+}
+
+// spreadBits moves each bit of u so there is a 0 bit between each.
+func spreadBits(u uint32) uint64 {
+	x := uint64(u)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// compactBits is the inverse of spreadBits, it takes every other bit.
+func compactBits(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return uint32(x)
+}
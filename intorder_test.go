@@ -0,0 +1,125 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrderSmall(t *testing.T) {
+	a, b := I{1, 2}, I{3, 5}
+	assert.Equal(t, []I{{1, 2}, {1, 3}, {1, 4}, {2, 2}, {2, 3}, {2, 4}}, a.ToColumns(b).Slice())
+	assert.Equal(t, []I{{1, 2}, {2, 2}, {2, 3}, {1, 3}, {1, 4}, {2, 4}}, a.ToSerpentine(b).Slice())
+	assert.Equal(t, []I{{2, 2}, {2, 1}, {1, 2}, {1, 1}}, I{2, 2}.ToColumns(I{0, 0}).Slice())
+
+	sq := I{0, 0}.ToSpiral(I{3, 3}).Slice()
+	assert.Equal(t, []I{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}, {0, 0}, {1, 0}, {2, 0}}, sq)
+
+	assert.Equal(t, []I{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 0}, {2, 1}}, I{}.ToMorton(I{3, 2}).Slice())
+	assert.Equal(t, []I{{0, 0}, {0, 1}, {1, 1}, {1, 0}}, I{}.ToHilbert(I{2, 2}).Slice())
+
+	for _, it := range []IntIterator{
+		a.ToColumns(a), a.ToSerpentine(a), a.ToSpiral(a), a.ToMorton(a), a.ToHilbert(a),
+	} {
+		assert.Equal(t, 0, it.Area())
+		assert.Len(t, it.Slice(), 0)
+	}
+}
+
+func TestOrderCoversRectangle(t *testing.T) {
+	rects := [][2]I{
+		{{0, 0}, {8, 8}},
+		{{2, 3}, {9, 5}},
+		{{5, 5}, {-2, 1}},
+		{{0, 0}, {1, 13}},
+		{{-3, 4}, {10, 5}},
+	}
+	orders := map[string]func(a, b I) IntIterator{
+		"columns":    I.ToColumns,
+		"serpentine": I.ToSerpentine,
+		"spiral":     I.ToSpiral,
+		"morton":     I.ToMorton,
+		"hilbert":    I.ToHilbert,
+	}
+	for name, fn := range orders {
+		for _, r := range rects {
+			expected := r[0].To(r[1]).Slice()
+			it := fn(r[0], r[1])
+			assert.Equal(t, len(expected), it.Area(), name)
+			got := it.Slice()
+			assert.ElementsMatch(t, expected, got, name)
+			var count int
+			it.Each(func(idx int, _ I) {
+				assert.Equal(t, count, idx)
+				count++
+			})
+			assert.Equal(t, len(expected), count)
+		}
+	}
+}
+
+func TestOrderAdjacent(t *testing.T) {
+	adjacent := func(pts []I) bool {
+		for i := 1; i < len(pts); i++ {
+			d := pts[i].Subtract(pts[i-1]).Abs()
+			if d.X+d.Y != 1 {
+				return false
+			}
+		}
+		return true
+	}
+	assert.True(t, adjacent(I{}.ToSerpentine(I{7, 5}).Slice()))
+	assert.True(t, adjacent(I{}.ToSpiral(I{9, 9}).Slice()))
+	assert.True(t, adjacent(I{}.ToHilbert(I{16, 16}).Slice()))
+	assert.True(t, adjacent(I{16, 16}.ToHilbert(I{0, 0}).Slice()))
+}
+
+func TestMorton(t *testing.T) {
+	assert.Equal(t, uint64(0), I{}.Morton())
+	assert.Equal(t, uint64(1), I{1, 0}.Morton())
+	assert.Equal(t, uint64(2), I{0, 1}.Morton())
+	assert.Equal(t, uint64(0xF), I{3, 3}.Morton())
+	for _, i := range []I{{0, 0}, {5, 9}, {1<<32 - 1, 7}, {123456, 1<<31 + 5}} {
+		assert.Equal(t, i, FromMorton(i.Morton()))
+	}
+}
+
+func TestOrderSkipsOutside(t *testing.T) {
+	// the orders must match walking the whole enclosing square and dropping
+	// the points outside the rectangle
+	walk := func(pts []I, size I) []I {
+		var out []I
+		for _, pt := range pts {
+			if pt.In(I{}, size) {
+				out = append(out, pt)
+			}
+		}
+		return out
+	}
+	for _, size := range []I{{1, 1}, {3, 2}, {7, 5}, {1, 13}, {17, 3}, {9, 16}, {33, 2}} {
+		n := 1
+		for n < size.X || n < size.Y {
+			n <<= 1
+		}
+		var morton, hilbert, spiral []I
+		for d := 0; d < n*n; d++ {
+			morton = append(morton, FromMorton(uint64(d)))
+			hilbert = append(hilbert, hilbertPoint(n, d))
+		}
+		s := &spiralOrder{}
+		spiral = append(spiral, s.reset(I{n + 2, n + 2}))
+		offset := spiral[0].Subtract(I{(size.X - 1) / 2, (size.Y - 1) / 2})
+		spiral[0] = spiral[0].Subtract(offset)
+		for d := 1; d < (n+2)*(n+2); d++ {
+			spiral = append(spiral, s.next(I{n + 2, n + 2}, d).Subtract(offset))
+		}
+		assert.Equal(t, walk(morton, size), I{}.ToMorton(size).Slice(), size)
+		assert.Equal(t, walk(hilbert, size), I{}.ToHilbert(size).Slice(), size)
+		assert.Equal(t, walk(spiral, size), I{}.ToSpiral(size).Slice(), size)
+	}
+
+	// a long thin rectangle would visit about 10^12 points without skipping
+	size := I{1000000, 2}
+	for _, it := range []IntIterator{I{}.ToSpiral(size), I{}.ToMorton(size), I{}.ToHilbert(size)} {
+		assert.Len(t, it.Slice(), size.Area())
+	}
+}
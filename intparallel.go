@@ -0,0 +1,125 @@
+package vec2d
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ParallelBatchSize is the number of points that Parallel sends to a worker at
+// a time.
+var ParallelBatchSize = 256
+
+type pointBatch struct {
+	idx int
+	pts []I
+}
+
+// ChanContext runs a go routine that will return the points of it on a
+// channel with a buffer of size buf. Unlike Chan, the go routine uses a Copy
+// of the iterator so it can continue to be used and the go routine stops and
+// closes the channel when ctx is done, so it will not leak if the points are
+// not consumed as long as ctx is canceled.
+func ChanContext(ctx context.Context, it IntIterator, buf int) <-chan I {
+	if buf < 0 {
+		buf = 0
+	}
+	c := make(chan I, buf)
+	it = Copy(it)
+	go func() {
+		defer close(c)
+		for pt, ok := it.Reset(); ok; pt, ok = it.Next() {
+			select {
+			case c <- pt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// Batches runs a go routine that will return the points of the iterator on a
+// channel in slices of up to size points. Like ChanContext, the go routine uses
+// a Copy of it and stops and closes the channel when ctx is done.
+func Batches(ctx context.Context, it IntIterator, size int) <-chan []I {
+	c := make(chan []I)
+	batches := batches(ctx, it, size)
+	go func() {
+		defer close(c)
+		for b := range batches {
+			select {
+			case c <- b.pts:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func batches(ctx context.Context, it IntIterator, size int) <-chan pointBatch {
+	if size < 1 {
+		size = 1
+	}
+	c := make(chan pointBatch)
+	it = Copy(it)
+	go func() {
+		defer close(c)
+		b := pointBatch{pts: make([]I, 0, size)}
+		for pt, ok := it.Reset(); ok; pt, ok = it.Next() {
+			b.pts = append(b.pts, pt)
+			if len(b.pts) < size {
+				continue
+			}
+			select {
+			case c <- b:
+			case <-ctx.Done():
+				return
+			}
+			b = pointBatch{
+				idx: it.Idx() + 1,
+				pts: make([]I, 0, size),
+			}
+		}
+		if len(b.pts) > 0 {
+			select {
+			case c <- b:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return c
+}
+
+// Parallel calls fn for each point in it using the given number of worker go
+// routines. If workers is less than 1, GOMAXPROCS workers are used. The points
+// are handed to the workers in batches of ParallelBatchSize so there is no
+// guarantee about the order fn is called in, but the index passed to fn is the
+// index of the point in it. The iteration is done on a Copy of it. Parallel
+// blocks until every point is processed or ctx is done and returns the error
+// from ctx if it stopped early.
+func Parallel(ctx context.Context, it IntIterator, workers int, fn func(idx int, pt I)) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	c := batches(ctx, it, ParallelBatchSize)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for b := range c {
+				for i, pt := range b.pts {
+					if ctx.Err() != nil {
+						return
+					}
+					fn(b.idx+i, pt)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
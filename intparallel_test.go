@@ -0,0 +1,101 @@
+package vec2d
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	a, b := I{1, 2}, I{6, 5}
+	its := map[string]IntIterator{
+		"to":         a.To(b),
+		"line":       a.LineTo(b),
+		"supercover": a.Supercover(b),
+		"circle":     a.Circle(3),
+		"disc":       a.Disc(3),
+		"spiral":     a.ToSpiral(b),
+		"hilbert":    a.ToHilbert(b),
+		"filter":     Filter(a.To(b), func(pt I) bool { return pt.X != 3 }),
+		"concat":     Concat(a.To(b), b.LineTo(a)),
+		"take":       Take(Step(Skip(a.ToMorton(b), 2), 2), 4),
+		"zip":        Zip(a.To(b), a.ToColumns(b), I.Add),
+		"union":      Union(a.LineTo(b), a.Supercover(b)),
+		"intersect":  Intersect(a.To(b), a.Disc(2)),
+		"offset":     Offset(a.ToSerpentine(b), I{1, 1}),
+	}
+	for name, it := range its {
+		expected := it.Slice()
+		it.Reset()
+		it.Next()
+		c := Copy(it)
+		assert.Equal(t, 1, it.Idx(), name)
+		assert.Equal(t, 0, c.Idx(), name)
+
+		// advancing the copy does not move the original
+		c.Next()
+		c.Next()
+		assert.Equal(t, 1, it.Idx(), name)
+		assert.Equal(t, expected[1], it.I(), name)
+		assert.Equal(t, expected, c.Slice(), name)
+	}
+}
+
+func TestChanContext(t *testing.T) {
+	it := I{}.To(I{3, 3})
+	var got []I
+	for pt := range ChanContext(context.Background(), it, 4) {
+		got = append(got, pt)
+	}
+	assert.Equal(t, it.Slice(), got)
+
+	// canceling stops the go routine and closes the channel
+	ctx, cancel := context.WithCancel(context.Background())
+	c := ChanContext(ctx, I{}.To(I{1000, 1000}), 0)
+	<-c
+	cancel()
+	var count int
+	for range c {
+		count++
+	}
+	assert.Less(t, count, 10)
+}
+
+func TestBatches(t *testing.T) {
+	it := I{}.To(I{5, 2})
+	var got [][]I
+	for b := range Batches(context.Background(), it, 4) {
+		got = append(got, b)
+	}
+	pts := it.Slice()
+	assert.Equal(t, [][]I{pts[:4], pts[4:8], pts[8:]}, got)
+}
+
+func TestParallel(t *testing.T) {
+	it := I{-10, -20}.To(I{40, 30})
+	expected := it.Slice()
+	var mux sync.Mutex
+	got := make(map[int]I)
+	err := Parallel(context.Background(), it, 4, func(idx int, pt I) {
+		mux.Lock()
+		got[idx] = pt
+		mux.Unlock()
+	})
+	assert.NoError(t, err)
+	assert.Len(t, got, len(expected))
+	for idx, pt := range expected {
+		assert.Equal(t, pt, got[idx])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int
+	err = Parallel(ctx, I{}.To(I{1000, 1000}), 1, func(idx int, pt I) {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 10, count)
+}
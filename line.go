@@ -1,6 +1,7 @@
 package vec2d
 
 import (
+	"iter"
 	"math"
 )
 
@@ -49,25 +50,85 @@ func (l Line) M() float64 {
 
 // Closest returns the point on the line closest to f
 func (l Line) Closest(f F) F {
+	return l(l.ProjectT(f))
+}
+
+// ProjectT returns the value of t where f projects onto the line, which is the
+// point on the line closest to f. If the line is a single point, 0 is
+// returned.
+func (l Line) ProjectT(f F) float64 {
+	p0 := l(0)
+	d := l(1).Subtract(p0)
+	m2 := d.Dot(d)
+	if m2 == 0 {
+		return 0
+	}
+	return f.Subtract(p0).Dot(d) / m2
+}
+
+// ClosestOnSegment returns the point on the line between t=0 and t=1 that is
+// closest to f.
+func (l Line) ClosestOnSegment(f F) F {
+	return l(math.Max(0, math.Min(1, l.ProjectT(f))))
+}
+
+// SignedDistance returns the distance from f to the line. It is positive if f
+// is on the left side of the line looking from t=0 towards t=1 (so the line
+// proceeds counter clockwise around f) and negative if it is on the right. If
+// the line is a single point, the distance to that point is returned.
+func (l Line) SignedDistance(f F) float64 {
 	p0 := l(0)
-	d := p0.Subtract(l(1))
-	dt := F{-d.Y, d.X} //tangent slope
-	l2 := f.LineTo(f.Add(dt))
-	t0, _ := l.Intersection(l2)
-	return l(t0)
+	d := l(1).Subtract(p0)
+	m := d.Mag()
+	if m == 0 {
+		return f.Distance(p0)
+	}
+	return d.Cross(f.Subtract(p0)) / m
+}
+
+// Distance returns the distance from f to the closest point on the line.
+func (l Line) Distance(f F) float64 {
+	return math.Abs(l.SignedDistance(f))
+}
+
+// Side returns 1 if f is on the left side of the line looking from t=0 towards
+// t=1, -1 if it is on the right side and 0 if it is on the line. The result
+// is exact, see Orient.
+func (l Line) Side(f F) int {
+	return sign(Orient(l(0), l(1), f))
+}
+
+// Perpendicular returns a line through f at a right angle to l. The direction
+// of the line is the direction of l rotated a quarter turn counter clockwise
+// and t=0 is at f.
+func (l Line) Perpendicular(f F) Line {
+	return f.LineTo(f.Add(l.Tangent(0).Perpendicular()))
+}
+
+// Parallel returns a line through f with the same direction as l. At t=0 it
+// is at f.
+func (l Line) Parallel(f F) Line {
+	return f.LineTo(f.Add(l.Tangent(0)))
+}
+
+// AngleTo returns the signed angle in radians to rotate the direction of l
+// onto the direction of l2. See F.AngleTo.
+func (l Line) AngleTo(l2 Line) float64 {
+	return l.Tangent(0).AngleTo(l2.Tangent(0))
 }
 
 // Intersection returns the points at which the lines intersect. Two values are
 // returned that indicate the index points at the line. If the lines do not
 // intersect, NaN will be returned for both values. If the lines are equivalent
 // NaN will be returned for both values because there is not a single
-// intersection point.
+// intersection point. Intersect and IntersectSegment can distinguish these
+// cases.
 func (l Line) Intersection(l2 Line) (float64, float64) {
 	a0, b0 := l(0), l2(0)
 	a1, b1 := l(1), l2(1)
 	da, db := a1.Subtract(a0), b1.Subtract(b0)
 
-	d := da.Cross(db)
+	d := crossDiff(a0, a1, b0, b1)
 	if d == 0 {
 		// lines do not intersect
 		return math.NaN(), math.NaN()
@@ -86,6 +147,21 @@ func (l Line) Intersection(l2 Line) (float64, float64) {
 	return ta, tb
 }
 
+// Intersect treats l and l2 as lines that extend forever in both directions.
+// Lines that are not parallel intersect at a point. Parallel lines do not
+// intersect unless they are collinear, in which case they overlap for all t.
+func (l Line) Intersect(l2 Line) Intersection {
+	return l.InfiniteLine().Intersect(l2.InfiniteLine())
+}
+
+// IntersectSegment treats l and l2 as the segments between t=0 and t=1. If the
+// segments are collinear and overlap, T and T1 give the overlap on l and U and
+// U1 give the same points on l2. Collinear is set for collinear segments even
+// when they do not overlap.
+func (l Line) IntersectSegment(l2 Line) Intersection {
+	return l.Segment().Intersect(l2.Segment())
+}
+
 // F is an alias of Line to fulfil Path
 func (l Line) F(t float64) F {
 	return l(t)
@@ -96,7 +172,7 @@ func (l Line) Tangent(t float64) F {
 	return l(1).Subtract(l(0))
 }
 
-// LineSegments links together a series of points and fulfils Curve.
+// LineSegments links together a series of points and fulfils Path.
 type LineSegments []F
 
 // F fulfills Curve on LineSegments
@@ -110,12 +186,30 @@ func (ls LineSegments) F(t float64) F {
 	}
 
 	// 4 points = 3 segments 0:2
-	ts := t * float64(ln-1)
-	ti := int(ts)
-	if ti > ln-2 {
-		ti = ln - 2
-	} else if ti < 0 {
-		ti = 0
+	ti, st := ls.segment(t)
+	return ls[ti].LineTo(ls[ti+1])(st)
+}
+
+// Vertices returns a sequence of the index and position of each point for use
+// with range.
+func (ls LineSegments) Vertices() iter.Seq2[int, F] {
+	return func(yield func(int, F) bool) {
+		for i, f := range ls {
+			if !yield(i, f) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns a sequence of the segments for use with range. The segment at
+// index i is the line from point i to point i+1.
+func (ls LineSegments) Edges() iter.Seq2[int, Line] {
+	return func(yield func(int, Line) bool) {
+		for i := 1; i < len(ls); i++ {
+			if !yield(i-1, ls[i-1].LineTo(ls[i])) {
+				return
+			}
+		}
 	}
-	return ls[ti].LineTo(ls[ti+1])(ts - float64(ti))
 }
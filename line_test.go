@@ -134,3 +134,107 @@ func TestLineSegments(t *testing.T) {
 	assert.Equal(t, F{1.5, 0.5}, ls.F(0.75))
 	assert.Equal(t, ls[2], ls.F(1))
 }
+
+func TestLineSegmentsSeq(t *testing.T) {
+	ls := LineSegments{{0, 0}, {1, 1}, {2, 0}}
+	var vs []F
+	for i, f := range ls.Vertices() {
+		assert.Equal(t, len(vs), i)
+		vs = append(vs, f)
+	}
+	assert.Equal(t, []F(ls), vs)
+
+	var count int
+	for i, l := range ls.Edges() {
+		assert.Equal(t, count, i)
+		assert.Equal(t, ls[i], l(0))
+		assert.Equal(t, ls[i+1], l(1))
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestLineProjection(t *testing.T) {
+	l := F{1, 1}.LineTo(F{5, 1})
+	assert.Equal(t, 0.5, l.ProjectT(F{3, 7}))
+	assert.Equal(t, -0.25, l.ProjectT(F{0, -2}))
+	assert.Equal(t, F{0, 1}, l.Closest(F{0, -2}))
+	assert.Equal(t, F{1, 1}, l.ClosestOnSegment(F{0, -2}))
+	assert.Equal(t, F{5, 1}, l.ClosestOnSegment(F{9, 3}))
+	assert.Equal(t, F{3, 1}, l.ClosestOnSegment(F{3, 3}))
+
+	p := F{2, 2}.LineTo(F{2, 2})
+	assert.Equal(t, 0.0, p.ProjectT(F{5, 5}))
+	assert.Equal(t, F{2, 2}, p.Closest(F{5, 5}))
+	assert.Equal(t, 5.0, p.SignedDistance(F{5, 6}))
+}
+
+func TestLineSide(t *testing.T) {
+	l := F{1, 1}.LineTo(F{5, 1})
+	assert.Equal(t, 6.0, l.SignedDistance(F{3, 7}))
+	assert.Equal(t, -3.0, l.SignedDistance(F{0, -2}))
+	assert.Equal(t, 3.0, l.Distance(F{0, -2}))
+	assert.Equal(t, 0.0, l.Distance(F{9, 1}))
+
+	assert.Equal(t, 1, l.Side(F{3, 7}))
+	assert.Equal(t, -1, l.Side(F{0, -2}))
+	assert.Equal(t, 0, l.Side(F{9, 1}))
+
+	// the sign of Side is exact even when the point is very close to the line
+	l = F{0, 0}.LineTo(F{3, 1})
+	assert.Equal(t, 1, l.Side(F{0.3, 0.1 + 1e-17}))
+	assert.Equal(t, 0, l.Side(F{0.75, 0.25}))
+}
+
+func TestLinePerpendicularParallel(t *testing.T) {
+	l := F{1, 1}.LineTo(F{3, 2})
+	p := l.Perpendicular(F{0, 5})
+	assert.Equal(t, F{0, 5}, p(0))
+	assert.Equal(t, F{-1, 7}, p(1))
+	assert.Equal(t, 0.0, l.Tangent(0).Dot(p.Tangent(0)))
+	assert.InDelta(t, math.Pi/2, l.AngleTo(p), 1e-12)
+	assert.InDelta(t, -math.Pi/2, p.AngleTo(l), 1e-12)
+
+	q := l.Parallel(F{0, 5})
+	assert.Equal(t, F{0, 5}, q(0))
+	assert.Equal(t, F{2, 6}, q(1))
+	assert.Equal(t, 0.0, l.AngleTo(q))
+	assert.InDelta(t, math.Pi, l.AngleTo(F{0, 0}.LineTo(F{-2, -1})), 1e-12)
+}
+
+func TestLineIntersectCollinear(t *testing.T) {
+	l := F{0, 0}.LineTo(F{2, 0})
+
+	// parallel lines are not collinear
+	x := l.Intersect(F{0, 1}.LineTo(F{1, 1}))
+	assert.Equal(t, IntersectNone, x.Kind)
+	assert.False(t, x.Collinear)
+
+	// collinear lines overlap everywhere
+	x = l.Intersect(F{5, 0}.LineTo(F{6, 0}))
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.True(t, x.Collinear)
+	assert.True(t, math.IsInf(x.T, -1))
+
+	x = l.Intersect(F{1, 1}.LineTo(F{1, 2}))
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 0.5, x.T)
+	assert.Equal(t, -1.0, x.U)
+
+	// collinear segments that do not overlap
+	x = l.IntersectSegment(F{5, 0}.LineTo(F{6, 0}))
+	assert.Equal(t, IntersectNone, x.Kind)
+	assert.True(t, x.Collinear)
+
+	x = l.IntersectSegment(F{3, 0}.LineTo(F{1, 0}))
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.True(t, x.Collinear)
+	assert.Equal(t, 0.5, x.T)
+	assert.Equal(t, 1.0, x.T1)
+	assert.Equal(t, 1.0, x.U)
+	assert.Equal(t, 0.5, x.U1)
+
+	x = l.IntersectSegment(F{1, 1}.LineTo(F{1, 2}))
+	assert.Equal(t, IntersectNone, x.Kind)
+	assert.False(t, x.Collinear)
+}
@@ -0,0 +1,192 @@
+package vec2d
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Length returns the sum of the lengths of the segments.
+func (ls LineSegments) Length() float64 {
+	var sum float64
+	for i := 1; i < len(ls); i++ {
+		sum += ls[i-1].Distance(ls[i])
+	}
+	return sum
+}
+
+// segment returns the index of the segment that t falls on and the value of t
+// within that segment. Values of t outside [0,1] fall on the first or last
+// segment.
+func (ls LineSegments) segment(t float64) (int, float64) {
+	n := len(ls) - 1
+	ts := t * float64(n)
+	ti := int(math.Floor(ts))
+	if ti > n-1 {
+		ti = n - 1
+	} else if ti < 0 {
+		ti = 0
+	}
+	return ti, ts - float64(ti)
+}
+
+// DistanceAt returns the distance along ls from the first point to the point
+// at t. Values of t outside [0,1] extend the first and last segment, the same
+// as F, so the distance may be negative or greater than Length. This adds up
+// the segments before t on every call, use Measure when calling it many times.
+func (ls LineSegments) DistanceAt(t float64) float64 {
+	if len(ls) < 2 {
+		return 0
+	}
+	ti, st := ls.segment(t)
+	var d float64
+	for i := 0; i < ti; i++ {
+		d += ls[i].Distance(ls[i+1])
+	}
+	return d + st*ls[ti].Distance(ls[ti+1])
+}
+
+// TAtDistance is the inverse of DistanceAt, it returns the value of t at a
+// distance d along ls. Segments with a length of 0 are skipped. Distances less
+// than 0 or greater than Length extend the first or last segment. This takes
+// O(N) time on every call, use Measure when calling it many times.
+func (ls LineSegments) TAtDistance(d float64) float64 {
+	n := len(ls) - 1
+	var acc, lastAcc, lastLn float64
+	last := -1
+	for i := 0; i < n; i++ {
+		ln := ls[i].Distance(ls[i+1])
+		if ln == 0 {
+			continue
+		}
+		if d <= acc+ln {
+			return (float64(i) + (d-acc)/ln) / float64(n)
+		}
+		last, lastAcc, lastLn = i, acc, ln
+		acc += ln
+	}
+	if last == -1 {
+		return 0
+	}
+	return (float64(last) + (d-lastAcc)/lastLn) / float64(n)
+}
+
+// AtDistance returns the point at a distance d along ls, so evenly spaced
+// distances give evenly spaced points regardless of the length of each
+// segment. See TAtDistance.
+func (ls LineSegments) AtDistance(d float64) F {
+	return ls.F(ls.TAtDistance(d))
+}
+
+// Measured holds LineSegments along with the distance from the first point to
+// each point so that distances can be looked up in O(log N) time. The
+// LineSegments must not be modified after calling Measure.
+type Measured struct {
+	LineSegments
+	// Distances holds the distance along the LineSegments to each point.
+	Distances []float64
+}
+
+// Measure returns ls with the distance to each point computed ahead of time.
+func (ls LineSegments) Measure() Measured {
+	m := Measured{
+		LineSegments: ls,
+		Distances:    make([]float64, len(ls)),
+	}
+	for i := 1; i < len(ls); i++ {
+		m.Distances[i] = m.Distances[i-1] + ls[i-1].Distance(ls[i])
+	}
+	return m
+}
+
+// Length returns the sum of the lengths of the segments.
+func (m Measured) Length() float64 {
+	if len(m.Distances) == 0 {
+		return 0
+	}
+	return m.Distances[len(m.Distances)-1]
+}
+
+// DistanceAt returns the same value as LineSegments.DistanceAt.
+func (m Measured) DistanceAt(t float64) float64 {
+	if len(m.LineSegments) < 2 {
+		return 0
+	}
+	ti, st := m.segment(t)
+	return m.Distances[ti] + st*(m.Distances[ti+1]-m.Distances[ti])
+}
+
+// TAtDistance returns the same value as LineSegments.TAtDistance.
+func (m Measured) TAtDistance(d float64) float64 {
+	n := len(m.LineSegments) - 1
+	if n < 1 {
+		return 0
+	}
+	ln := func(i int) float64 { return m.Distances[i+1] - m.Distances[i] }
+	// the first segment that ends at or after d, skipping segments with a
+	// length of 0
+	i := sort.Search(n, func(i int) bool { return d <= m.Distances[i+1] })
+	for i < n && ln(i) == 0 {
+		i++
+	}
+	if i == n {
+		for i = n - 1; i >= 0 && ln(i) == 0; i-- {
+		}
+		if i < 0 {
+			return 0
+		}
+	}
+	return (float64(i) + (d-m.Distances[i])/ln(i)) / float64(n)
+}
+
+// AtDistance returns the same point as LineSegments.AtDistance.
+func (m Measured) AtDistance(d float64) F {
+	return m.F(m.TAtDistance(d))
+}
+
+// Closest returns the point on ls that is closest to f along with the value of
+// t at that point and the index of the segment it is on. The segment at index i
+// is from point i to point i+1. If ls has fewer than 2 points there are no
+// segments, so idx is -1 and the point is the only point or the zero value.
+func (ls LineSegments) Closest(f F) (pt F, t float64, idx int) {
+	switch len(ls) {
+	case 0:
+		return F{}, 0, -1
+	case 1:
+		return ls[0], 0, -1
+	}
+	n := float64(len(ls) - 1)
+	best := math.Inf(1)
+	for i := 1; i < len(ls); i++ {
+		l := ls[i-1].LineTo(ls[i])
+		st := math.Max(0, math.Min(1, l.ProjectT(f)))
+		p := pointAt(ls[i-1], ls[i], st)
+		if d := p.Distance(f); d < best {
+			best, pt, t, idx = d, p, (float64(i-1)+st)/n, i-1
+		}
+	}
+	return
+}
+
+// Tangent returns the direction of the segment at t, scaled by the number of
+// segments so that it is the derivative of F. At a vertex the tangent of the
+// following segment is returned. Fulfills Path.
+func (ls LineSegments) Tangent(t float64) F {
+	if len(ls) < 2 {
+		return F{}
+	}
+	ti, _ := ls.segment(t)
+	return ls[ti+1].Subtract(ls[ti]).ScalarMultiply(float64(len(ls) - 1))
+}
+
+// Insert inserts pts before the point at idx and returns the result. Like
+// slices.Insert, the underlying array of ls may be modified.
+func (ls LineSegments) Insert(idx int, pts ...F) LineSegments {
+	return slices.Insert(ls, idx, pts...)
+}
+
+// Remove removes the point at idx and returns the result. Like slices.Delete,
+// the underlying array of ls is modified.
+func (ls LineSegments) Remove(idx int) LineSegments {
+	return slices.Delete(ls, idx, idx+1)
+}
@@ -0,0 +1,121 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLineSegmentsLength(t *testing.T) {
+	ls := LineSegments{{0, 0}, {3, 4}, {3, 5}, {3, 5}, {7, 5}}
+	assert.Equal(t, 10.0, ls.Length())
+	assert.Equal(t, 0.0, LineSegments{{1, 1}}.Length())
+	assert.Equal(t, 0.0, LineSegments(nil).Length())
+
+	assert.Equal(t, 0.0, ls.DistanceAt(0))
+	assert.Equal(t, 5.0, ls.DistanceAt(0.25))
+	assert.Equal(t, 6.0, ls.DistanceAt(0.5))
+	assert.Equal(t, 6.0, ls.DistanceAt(0.75))
+	assert.Equal(t, 8.0, ls.DistanceAt(0.875))
+	assert.Equal(t, 10.0, ls.DistanceAt(1))
+	assert.Equal(t, -2.5, ls.DistanceAt(-0.125))
+	assert.Equal(t, 14.0, ls.DistanceAt(1.25))
+
+	assert.Equal(t, 0.0, ls.TAtDistance(0))
+	assert.Equal(t, 0.125, ls.TAtDistance(2.5))
+	assert.Equal(t, 0.25, ls.TAtDistance(5))
+	assert.Equal(t, 0.5, ls.TAtDistance(6))
+	assert.Equal(t, 0.875, ls.TAtDistance(8))
+	assert.Equal(t, 1.0, ls.TAtDistance(10))
+	assert.Equal(t, -0.125, ls.TAtDistance(-2.5))
+	assert.Equal(t, 1.25, ls.TAtDistance(14))
+	assert.Equal(t, 0.0, LineSegments{{1, 1}, {1, 1}}.TAtDistance(3))
+
+	assert.Equal(t, F{1.5, 2}, ls.AtDistance(2.5))
+	assert.Equal(t, F{3, 4.5}, ls.AtDistance(5.5))
+	assert.Equal(t, F{5, 5}, ls.AtDistance(8))
+
+	// evenly spaced distances are evenly spaced points
+	for d := 0.0; d <= 10; d += 0.5 {
+		assert.InDelta(t, d, ls.DistanceAt(ls.TAtDistance(d)), 1e-12)
+	}
+}
+
+func TestMeasured(t *testing.T) {
+	ls := LineSegments{{0, 0}, {3, 4}, {3, 5}, {3, 5}, {7, 5}}
+	m := ls.Measure()
+	assert.Equal(t, []float64{0, 5, 6, 6, 10}, m.Distances)
+	assert.Equal(t, ls.Length(), m.Length())
+	for _, ts := range []float64{-0.125, 0, 0.25, 0.5, 0.75, 0.875, 1, 1.25} {
+		assert.Equal(t, ls.DistanceAt(ts), m.DistanceAt(ts))
+	}
+	for _, d := range []float64{-2.5, 0, 2.5, 5, 5.5, 6, 8, 10, 14} {
+		assert.Equal(t, ls.TAtDistance(d), m.TAtDistance(d))
+		assert.Equal(t, ls.AtDistance(d), m.AtDistance(d))
+	}
+
+	// zero length segments at the ends are skipped
+	ls = LineSegments{{1, 1}, {1, 1}, {4, 5}, {4, 5}}
+	m = ls.Measure()
+	for _, d := range []float64{-1, 0, 2, 5, 7} {
+		assert.Equal(t, ls.TAtDistance(d), m.TAtDistance(d))
+	}
+
+	for _, ls := range []LineSegments{nil, {{1, 1}}, {{1, 1}, {1, 1}}} {
+		m := ls.Measure()
+		assert.Equal(t, 0.0, m.Length())
+		assert.Equal(t, 0.0, m.DistanceAt(0.5))
+		assert.Equal(t, 0.0, m.TAtDistance(3))
+	}
+}
+
+func TestLineSegmentsClosest(t *testing.T) {
+	ls := LineSegments{{0, 0}, {4, 0}, {4, 4}}
+	pt, ct, idx := ls.Closest(F{2, 1})
+	assert.Equal(t, F{2, 0}, pt)
+	assert.Equal(t, 0.25, ct)
+	assert.Equal(t, 0, idx)
+
+	pt, ct, idx = ls.Closest(F{5, 3})
+	assert.Equal(t, F{4, 3}, pt)
+	assert.Equal(t, 0.875, ct)
+	assert.Equal(t, 1, idx)
+
+	pt, ct, idx = ls.Closest(F{-1, -1})
+	assert.Equal(t, F{0, 0}, pt)
+	assert.Equal(t, 0.0, ct)
+	assert.Equal(t, 0, idx)
+
+	pt, _, idx = LineSegments{{1, 2}}.Closest(F{5, 5})
+	assert.Equal(t, F{1, 2}, pt)
+	assert.Equal(t, -1, idx)
+	_, _, idx = LineSegments(nil).Closest(F{5, 5})
+	assert.Equal(t, -1, idx)
+}
+
+func TestLineSegmentsTangent(t *testing.T) {
+	var p Path = LineSegments{{0, 0}, {1, 1}, {3, 1}}
+	assert.Equal(t, F{2, 2}, p.Tangent(0))
+	assert.Equal(t, F{2, 2}, p.Tangent(0.25))
+	assert.Equal(t, F{4, 0}, p.Tangent(0.5))
+	assert.Equal(t, F{4, 0}, p.Tangent(1))
+	assert.Equal(t, F{2, 2}, p.Tangent(-1))
+
+	// the tangent is the derivative of F
+	const h = 1e-6
+	d := p.F(0.7 + h).Subtract(p.F(0.7 - h)).ScalarMultiply(1 / (2 * h))
+	assert.True(t, d.Near(p.Tangent(0.7), 1e-6))
+
+	assert.Equal(t, F{}, LineSegments{{1, 1}}.Tangent(0.5))
+}
+
+func TestLineSegmentsInsertRemove(t *testing.T) {
+	ls := LineSegments{{0, 0}, {2, 0}}
+	ls = ls.Insert(1, F{1, 1})
+	assert.Equal(t, LineSegments{{0, 0}, {1, 1}, {2, 0}}, ls)
+	ls = ls.Insert(3, F{3, 3}, F{4, 4})
+	assert.Equal(t, LineSegments{{0, 0}, {1, 1}, {2, 0}, {3, 3}, {4, 4}}, ls)
+	ls = ls.Remove(0)
+	assert.Equal(t, LineSegments{{1, 1}, {2, 0}, {3, 3}, {4, 4}}, ls)
+	ls = ls.Remove(3)
+	assert.Equal(t, LineSegments{{1, 1}, {2, 0}, {3, 3}}, ls)
+}
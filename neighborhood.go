@@ -0,0 +1,94 @@
+package vec2d
+
+// Metric defines how distance is measured when building a Neighborhood.
+type Metric byte
+
+const (
+	// Manhattan distance is |X| + |Y|. It produces a von Neumann
+	// neighborhood, a diamond.
+	Manhattan Metric = iota
+	// Chebyshev distance is the greater of |X| and |Y|. It produces a Moore
+	// neighborhood, a square.
+	Chebyshev
+	// Euclidean distance is the length of the vector. It produces a disc.
+	Euclidean
+)
+
+// Within returns true if d is no more than r from the origin when measured
+// with the Metric.
+func (m Metric) Within(d I, r int) bool {
+	d = d.Abs()
+	switch m {
+	case Manhattan:
+		return d.X+d.Y <= r
+	case Chebyshev:
+		return d.X <= r && d.Y <= r
+	}
+	return d.Dot(d) <= r*r
+}
+
+// Neighborhood returns a Neighborhood of radius r using the Metric.
+func (m Metric) Neighborhood(r int) Neighborhood {
+	return Neighborhood{
+		Radius: r,
+		Metric: m,
+	}
+}
+
+// Neighborhood describes the points around a center point that are within
+// Radius of the center.
+type Neighborhood struct {
+	Radius int
+	Metric Metric
+	// ExcludeCenter removes the center point from the neighborhood.
+	ExcludeCenter bool
+	// Size clips the points returned by Around to those between the origin
+	// (inclusive) and Size (exclusive), the same as the points in a grid of
+	// that size. If Size is zero, the points are not clipped.
+	Size I
+}
+
+// VonNeumann returns a Neighborhood using Manhattan distance. With a radius of
+// 1 it is the center and the 4 orthogonally adjacent points.
+func VonNeumann(r int) Neighborhood {
+	return Manhattan.Neighborhood(r)
+}
+
+// Moore returns a Neighborhood using Chebyshev distance. With a radius of 1 it
+// is the center and the 8 surrounding points.
+func Moore(r int) Neighborhood {
+	return Chebyshev.Neighborhood(r)
+}
+
+// Offsets returns the position of each point in the neighborhood relative to
+// the center. The offsets are in the same order as To, row by row starting
+// from {-Radius, -Radius}. Size is not applied to the offsets.
+func (n Neighborhood) Offsets() []I {
+	r := n.Radius
+	if r < 0 {
+		r = -r
+	}
+	var out []I
+	for it, d, ok := (I{-r, -r}).To(I{r + 1, r + 1}).Start(); ok; d, ok = it.Next() {
+		if (n.ExcludeCenter && d == I{}) || !n.Metric.Within(d, r) {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// Around returns an iterator over the points in the neighborhood of center, in
+// the same order as Offsets. If Size is set, points outside of it are skipped.
+func (n Neighborhood) Around(center I) IntIterator {
+	offsets := n.Offsets()
+	pts := make([]I, 0, len(offsets))
+	for _, d := range offsets {
+		pt := center.Add(d)
+		if n.Size != (I{}) && !pt.In(I{}, n.Size) {
+			continue
+		}
+		pts = append(pts, pt)
+	}
+	return newPointsIterator(pts)
+}
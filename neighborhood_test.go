@@ -0,0 +1,40 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNeighborhoodOffsets(t *testing.T) {
+	assert.Equal(t, []I{{0, -1}, {-1, 0}, {0, 0}, {1, 0}, {0, 1}}, VonNeumann(1).Offsets())
+	assert.Equal(t, I{-1, -1}.To(I{2, 2}).Slice(), Moore(1).Offsets())
+
+	n := Moore(1)
+	n.ExcludeCenter = true
+	assert.Len(t, n.Offsets(), 8)
+	assert.NotContains(t, n.Offsets(), I{})
+
+	assert.Len(t, VonNeumann(2).Offsets(), 13)
+	assert.Len(t, Moore(2).Offsets(), 25)
+	assert.Len(t, Euclidean.Neighborhood(2).Offsets(), 13)
+	assert.Len(t, Euclidean.Neighborhood(3).Offsets(), 29)
+	for _, d := range Euclidean.Neighborhood(5).Offsets() {
+		assert.True(t, d.Mag() <= 5)
+	}
+}
+
+func TestNeighborhoodAround(t *testing.T) {
+	n := VonNeumann(1)
+	assert.Equal(t, []I{{5, 4}, {4, 5}, {5, 5}, {6, 5}, {5, 6}}, n.Around(I{5, 5}).Slice())
+
+	n.Size = I{3, 3}
+	n.ExcludeCenter = true
+	it := n.Around(I{0, 0})
+	assert.Equal(t, 2, it.Area())
+	assert.Equal(t, []I{{1, 0}, {0, 1}}, it.Slice())
+
+	m := Moore(2)
+	m.Size = I{10, 10}
+	assert.Equal(t, 9, m.Around(I{9, 9}).Area())
+	assert.Equal(t, 25, m.Around(I{5, 5}).Area())
+}
@@ -0,0 +1,128 @@
+package vec2d
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseErr is returned when a string cannot be parsed into a vector or shape.
+// The message includes the input and the reason it could not be parsed.
+type ParseErr string
+
+// Error fulfils the error interface
+func (p ParseErr) Error() string {
+	return string(p)
+}
+
+func newParseErr(typ, s, reason string) ParseErr {
+	return ParseErr("vec2d: cannot parse " + strconv.Quote(s) + " as " + typ + ": " + reason)
+}
+
+// parsePair takes a string of the form "(a, b)" and returns a and b with the
+// surrounding whitespace removed.
+func parsePair(typ, s string) (string, string, error) {
+	t := strings.TrimSpace(s)
+	if !strings.HasPrefix(t, "(") {
+		return "", "", newParseErr(typ, s, "missing opening '('")
+	}
+	if !strings.HasSuffix(t, ")") {
+		return "", "", newParseErr(typ, s, "missing closing ')'")
+	}
+	a, b, ok := strings.Cut(t[1:len(t)-1], ",")
+	if !ok {
+		return "", "", newParseErr(typ, s, "expected 2 values separated by ','")
+	}
+	if strings.Contains(b, ",") {
+		return "", "", newParseErr(typ, s, "expected only 2 values")
+	}
+	return strings.TrimSpace(a), strings.TrimSpace(b), nil
+}
+
+func parseFloat(typ, s, name, v string) (float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, newParseErr(typ, s, "invalid "+name+" value "+strconv.Quote(v))
+	}
+	return f, nil
+}
+
+// ParseF parses a string in the format produced by F.String, "(X, Y)". Any
+// amount of whitespace is allowed around the values and the values may have
+// any precision.
+func ParseF(s string) (F, error) {
+	a, b, err := parsePair("F", s)
+	if err != nil {
+		return F{}, err
+	}
+	x, err := parseFloat("F", s, "X", a)
+	if err != nil {
+		return F{}, err
+	}
+	y, err := parseFloat("F", s, "Y", b)
+	if err != nil {
+		return F{}, err
+	}
+	return F{x, y}, nil
+}
+
+// ParseI parses a string in the format produced by I.String, "(X, Y)". Any
+// amount of whitespace is allowed around the values.
+func ParseI(s string) (I, error) {
+	a, b, err := parsePair("I", s)
+	if err != nil {
+		return I{}, err
+	}
+	x, err := strconv.Atoi(a)
+	if err != nil {
+		return I{}, newParseErr("I", s, "invalid X value "+strconv.Quote(a))
+	}
+	y, err := strconv.Atoi(b)
+	if err != nil {
+		return I{}, newParseErr("I", s, "invalid Y value "+strconv.Quote(b))
+	}
+	return I{x, y}, nil
+}
+
+// ParseP parses a string in the format produced by P.String, "(M, A rad )".
+// The "rad" suffix is optional and any amount of whitespace is allowed around
+// the values.
+func ParseP(s string) (P, error) {
+	a, b, err := parsePair("P", s)
+	if err != nil {
+		return P{}, err
+	}
+	b = strings.TrimSpace(strings.TrimSuffix(b, "rad"))
+	m, err := parseFloat("P", s, "M", a)
+	if err != nil {
+		return P{}, err
+	}
+	ang, err := parseFloat("P", s, "A", b)
+	if err != nil {
+		return P{}, err
+	}
+	return P{m, ang}, nil
+}
+
+// parseFs parses a list of vectors separated by colons.
+func parseFs(typ, s string) ([]F, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	strs := strings.Split(s, ":")
+	fs := make([]F, len(strs))
+	for i, str := range strs {
+		f, err := ParseF(str)
+		if err != nil {
+			return nil, newParseErr(typ, s, "vertex "+strconv.Itoa(i)+": "+err.Error())
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+// ParsePolygon parses a string in the format produced by Polygon.String, a
+// list of vectors separated by colons. Any amount of whitespace is allowed
+// around each vector.
+func ParsePolygon(s string) (Polygon, error) {
+	return parseFs("Polygon", s)
+}
@@ -0,0 +1,76 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseF(t *testing.T) {
+	f := F{1.25, -3.5}
+	got, err := ParseF(f.String())
+	assert.NoError(t, err)
+	assert.Equal(t, f, got)
+
+	got, err = ParseF("  (\t1.25,-3.5000000 )\n")
+	assert.NoError(t, err)
+	assert.Equal(t, f, got)
+
+	got, err = ParseF("(1e3, .5)")
+	assert.NoError(t, err)
+	assert.Equal(t, F{1000, 0.5}, got)
+
+	for _, s := range []string{"", "1, 2)", "(1, 2", "(1 2)", "(1, 2, 3)", "(x, 2)", "(1, )"} {
+		_, err := ParseF(s)
+		assert.Error(t, err, s)
+		assert.IsType(t, ParseErr(""), err)
+	}
+	_, err = ParseF("(1, y)")
+	assert.Equal(t, `vec2d: cannot parse "(1, y)" as F: invalid Y value "y"`, err.Error())
+}
+
+func TestParseI(t *testing.T) {
+	i := I{-7, 12}
+	got, err := ParseI(i.String())
+	assert.NoError(t, err)
+	assert.Equal(t, i, got)
+
+	got, err = ParseI("( 3 ,4 )")
+	assert.NoError(t, err)
+	assert.Equal(t, I{3, 4}, got)
+
+	_, err = ParseI("(1.5, 2)")
+	assert.Error(t, err)
+}
+
+func TestParseP(t *testing.T) {
+	p := P{2, 0.5}
+	got, err := ParseP(p.String())
+	assert.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	got, err = ParseP("(2,0.5)")
+	assert.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	got, err = ParseP("( 2 , 0.5rad)")
+	assert.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	_, err = ParseP("(2, 0.5 deg)")
+	assert.Error(t, err)
+}
+
+func TestParsePolygon(t *testing.T) {
+	p := Polygon{{0, 0}, {1, 0}, {1, 1.5}}
+	got, err := ParsePolygon(p.String())
+	assert.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	got, err = ParsePolygon("(0, 0) : (1,0):\n(1, 1.5)")
+	assert.NoError(t, err)
+	assert.Equal(t, p, got)
+
+	_, err = ParsePolygon("(0, 0):(1, 0):(1 1)")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "vertex 2")
+}
@@ -4,6 +4,11 @@ package vec2d
 // a 2D-float64 point
 type Curve func(t float64) F
 
+// F returns the point at t. Fulfills Curver.
+func (c Curve) F(t float64) F {
+	return c(t)
+}
+
 // Curver is an object that has a method named F fulling the Curve interface
 type Curver interface {
 	F(t float64) F
@@ -27,6 +27,61 @@ func (p P) Subtract(p2 P) P {
 	return p.F().Subtract(p2.F()).P()
 }
 
+// Scale returns p with the magnitude multiplied by s.
+func (p P) Scale(s float64) P {
+	p.M *= s
+	return p
+}
+
+// Rotate returns p rotated by a radians.
+func (p P) Rotate(a float64) P {
+	p.A += a
+	return p
+}
+
+// Normalize returns an equivalent polar vector with a non-negative magnitude
+// and an angle in the range [-π, π).
+func (p P) Normalize() P {
+	if p.M < 0 {
+		p.M, p.A = -p.M, p.A+Pi
+	}
+	p.A = wrapAngle(p.A)
+	return p
+}
+
+// NormalizePositive returns an equivalent polar vector with a non-negative
+// magnitude and an angle in the range [0, 2π).
+func (p P) NormalizePositive() P {
+	p = p.Normalize()
+	if p.A < 0 {
+		p.A += Tau
+		if p.A >= Tau {
+			p.A = 0
+		}
+	}
+	return p
+}
+
+// Slerp interpolates between p at t=0 and p2 at t=1. The magnitude is
+// interpolated linearly and the angle is interpolated along the shorter arc.
+func (p P) Slerp(p2 P, t float64) P {
+	p, p2 = p.Normalize(), p2.Normalize()
+	return P{
+		M: p.M + (p2.M-p.M)*t,
+		A: wrapAngle(p.A + wrapAngle(p2.A-p.A)*t),
+	}
+}
+
+// wrapAngle returns the equivalent angle in the range [-π, π).
+func wrapAngle(a float64) float64 {
+	a -= Tau * math.Floor((a+Pi)/Tau)
+	if a >= Pi {
+		a -= Tau
+	}
+	return a
+}
+
+// String fulfills Stringer, returns the vector as "(M, A rad )"
 func (p P) String() string {
 	return strings.Join([]string{
 		"(",
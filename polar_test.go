@@ -0,0 +1,48 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPolarScaleRotate(t *testing.T) {
+	p := P{2, 1}
+	assert.Equal(t, P{6, 1}, p.Scale(3))
+	assert.Equal(t, P{2, 1.5}, p.Rotate(0.5))
+}
+
+func TestPolarNormalize(t *testing.T) {
+	tt := []struct {
+		p, neg, pos P
+	}{
+		{P{1, 0}, P{1, 0}, P{1, 0}},
+		{P{1, Pi}, P{1, -Pi}, P{1, Pi}},
+		{P{1, 3 * Pi / 2}, P{1, -Pi / 2}, P{1, 3 * Pi / 2}},
+		{P{2, -Pi / 2}, P{2, -Pi / 2}, P{2, 3 * Pi / 2}},
+		{P{-1, 0}, P{1, -Pi}, P{1, Pi}},
+		{P{1, 5 * Tau}, P{1, 0}, P{1, 0}},
+	}
+	for _, tc := range tt {
+		n := tc.p.Normalize()
+		assert.Equal(t, tc.neg.M, n.M)
+		assert.InDelta(t, tc.neg.A, n.A, 1e-10)
+		assert.True(t, n.A >= -Pi && n.A < Pi)
+
+		n = tc.p.NormalizePositive()
+		assert.Equal(t, tc.pos.M, n.M)
+		assert.InDelta(t, tc.pos.A, n.A, 1e-10)
+		assert.True(t, n.A >= 0 && n.A < Tau)
+	}
+}
+
+func TestPolarSlerp(t *testing.T) {
+	a := P{1, Deg(170)}
+	b := P{3, Deg(-170)}
+	assert.Equal(t, a.Normalize(), a.Slerp(b, 0))
+	m := a.Slerp(b, 0.5)
+	assert.InDelta(t, 2, m.M, 1e-10)
+	assert.InDelta(t, Pi, m.NormalizePositive().A, 1e-10)
+	q := a.Slerp(b, 0.25)
+	assert.InDelta(t, Deg(175), q.A, 1e-10)
+	assert.True(t, b.F().Near(a.Slerp(b, 1).F(), 1e-10))
+}
@@ -1,6 +1,7 @@
 package vec2d
 
 import (
+	"iter"
 	"math"
 	"sort"
 	"strings"
@@ -68,20 +69,49 @@ func (p Polygon) Centroid() F {
 // Contains returns true of the point f is inside of the polygon
 func (p Polygon) Contains(f F) bool {
 	// https://en.wikipedia.org/wiki/Point_in_polygon#Ray_casting_algorithm
-	ray := f.LineTo(f.Add(F{1, 0}))
+	// A ray is cast in the +X direction. Each side is treated as including its
+	// lower end point and excluding its upper end point so that a ray through a
+	// vertex is counted exactly once. Orient decides which side of the ray the
+	// crossing is on without computing the intersection.
 	prev := p[len(p)-1]
 	var itersects int
 	for _, cur := range p {
-		side := prev.LineTo(cur)
-		ri, si := ray.Intersection(side)
-		if ri > 0 && si >= 0 && si < 1 {
-			itersects++
+		if (prev.Y > f.Y) != (cur.Y > f.Y) {
+			o := Orient(prev, cur, f)
+			if (cur.Y > prev.Y && o > 0) || (cur.Y < prev.Y && o < 0) {
+				itersects++
+			}
 		}
 		prev = cur
 	}
 	return itersects&1 == 1
 }
 
+// Vertices returns a sequence of the index and position of each vertex for use
+// with range.
+func (p Polygon) Vertices() iter.Seq2[int, F] {
+	return func(yield func(int, F) bool) {
+		for i, f := range p {
+			if !yield(i, f) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns a sequence of the sides of the polygon for use with range. The
+// side at index i is the line from vertex i to vertex i+1, with the last side
+// returning to the first vertex.
+func (p Polygon) Edges() iter.Seq2[int, Line] {
+	return func(yield func(int, Line) bool) {
+		for i, f := range p {
+			if !yield(i, f.LineTo(p[(i+1)%len(p)])) {
+				return
+			}
+		}
+	}
+}
+
 // Perimeter returns the total length of the perimeter
 func (p Polygon) Perimeter() float64 {
 	var sum float64
@@ -99,43 +129,45 @@ func (p Polygon) Perimeter() float64 {
 func (p Polygon) GetAngles() ([]int, []int) {
 	var ccw []int
 	var cw []int
-	prevIdx := len(p) - 1
-	prevF := p[prevIdx]
-	prevPlr := p[prevIdx-1].Subtract(prevF).P()
-	for i, f := range p {
-		curPlr := prevF.Subtract(f).P()
-		a := curPlr.A - prevPlr.A
-		if a < 0 {
-			a += 2 * math.Pi
-		}
-		if a > math.Pi {
-			cw = append(cw, prevIdx)
-		} else if a < math.Pi {
-			ccw = append(ccw, prevIdx)
+	ln := len(p)
+	for i := range p {
+		switch p.turn(i) {
+		case 1:
+			ccw = append(ccw, (i+ln-1)%ln)
+		case -1:
+			cw = append(cw, (i+ln-1)%ln)
 		}
-		prevPlr, prevF, prevIdx = curPlr, f, i
 	}
 	return ccw, cw
 }
 
+// turn returns the direction of the turn at the vertex before i. A counter
+// clockwise turn returns 1 and a clockwise turn returns -1. If the sides
+// continue in a straight line, that is treated as counter clockwise. If the
+// second side doubles back along the first, 0 is returned.
+func (p Polygon) turn(i int) int {
+	ln := len(p)
+	a, b, c := p[(i+ln-2)%ln], p[(i+ln-1)%ln], p[i]
+	if o := sign(Orient(a, b, c)); o != 0 {
+		return o
+	}
+	if b.Subtract(a).Dot(c.Subtract(b)) < 0 {
+		return 0
+	}
+	return 1
+}
+
 // CountAngles returns the number of counter clockwise and clockwise angles
 func (p Polygon) CountAngles() (int, int) {
 	var ccw int
 	var cw int
-	prevF := p[len(p)-1]
-	prevPlr := p[len(p)-2].Subtract(prevF).P()
-	for _, f := range p {
-		curPlr := prevF.Subtract(f).P()
-		a := curPlr.A - prevPlr.A
-		if a < 0 {
-			a += 2 * math.Pi
-		}
-		if a > math.Pi {
-			cw++
-		} else if a < math.Pi {
+	for i := range p {
+		switch p.turn(i) {
+		case 1:
 			ccw++
+		case -1:
+			cw++
 		}
-		prevPlr, prevF = curPlr, f
 	}
 	return ccw, cw
 }
@@ -164,8 +196,7 @@ func (p Polygon) CounterClockwise() bool {
 
 		prevPlr, prevF, prevIdx = curPlr, f, i
 	}
-	sum -= math.Pi * 2
-	return sum > -1E-10 && sum < 1E-10
+	return Near(sum, math.Pi*2, Epsilon)
 }
 
 // FindTriangles returns the index sets of the polygon broken up into triangles.
@@ -191,18 +222,25 @@ func (p Polygon) FindTriangles() [][3]int {
 			cur[i] = p[idx]
 		}
 
-		for i0 := 0; true; i0++ {
+		// Degenerate diagonals are only accepted on the second pass if there
+		// is no other choice.
+		found := false
+		for i := 0; !found && i < 2*len(idxMp); i++ {
+			i0 := i % len(idxMp)
 			i1 := (i0 + 1) % len(idxMp)
 			i2 := (i0 + 2) % len(idxMp)
 			ln := cur[i0].LineTo(cur[i2])
 			if !cur.Contains(ln(0.5)) {
 				continue
 			}
-			if _, idx, _ := p.Intersects(ln); idx != -1 {
+			if p.crossesSide(Segment{cur[i0], cur[i2]}, i < len(idxMp)) {
 				continue
 			}
 			ts = append(ts, [3]int{idxMp[i0], idxMp[i1], idxMp[i2]})
 			idxMp = append(idxMp[0:i1], idxMp[i1+1:]...)
+			found = true
+		}
+		if !found {
 			break
 		}
 	}
@@ -210,14 +248,33 @@ func (p Polygon) FindTriangles() [][3]int {
 	return ts
 }
 
+// crossesSide returns true if s crosses a side of the polygon at a point that
+// is not the end of either. If degenerate is true, s is also rejected if it
+// overlaps a side or passes through a vertex.
+func (p Polygon) crossesSide(s Segment, degenerate bool) bool {
+	for i, f := range p {
+		x := s.Intersect(Segment{f, p[(i+1)%len(p)]})
+		switch {
+		case x.Kind == IntersectNone:
+		case degenerate && (x.Kind == IntersectOverlap || (x.T > 0 && x.T < 1)):
+			return true
+		case x.Kind == IntersectPoint && x.T > 0 && x.T < 1 && x.U > 0 && x.U < 1:
+			return true
+		}
+	}
+	return false
+}
+
 // Intersects returns the first side that is intersected by the given
 // lineSegment, returning the parametic t for the lineSegment, the index of the
-// side and the parametric t of the side
+// side and the parametric t of the side. Only crossings strictly inside both
+// the lineSegment and the side are counted. If nothing crosses, the values of t
+// are NaN and the index is -1. IntersectsSegment includes the ends.
 func (p Polygon) Intersects(lineSegment Line) (lineT float64, idx int, sideT float64) {
 	lineT = math.NaN()
 	idx = -1
 	sideT = math.NaN()
-	ln := len(p) - 1
+	ln := len(p)
 	for i, f := range p {
 		side := f.LineTo(p[(i+1)%ln])
 		t0, t1 := lineSegment.Intersection(side)
@@ -232,30 +289,87 @@ func (p Polygon) Intersects(lineSegment Line) (lineT float64, idx int, sideT flo
 	return
 }
 
-// NonIntersecting returns false if any two sides intersect. This requires
-// O(N^2) time to check.
+// IntersectsSegment returns the first intersection of s with a side of the
+// polygon, ordered by T along s, and the index of the side. Unlike Intersects,
+// the ends of s and the sides are included and a side that s overlaps is
+// returned with the start of the overlap. If there is no intersection, the
+// zero Intersection and -1 are returned.
+func (p Polygon) IntersectsSegment(s Segment) (x Intersection, idx int) {
+	idx = -1
+	for i, f := range p {
+		sx := s.Intersect(Segment{f, p[(i+1)%len(p)]})
+		if sx.Kind == IntersectNone {
+			continue
+		}
+		if idx == -1 || sx.T < x.T {
+			x, idx = sx, i
+		}
+	}
+	return
+}
+
+// NonIntersecting returns false if any two sides that are not adjacent
+// intersect. This uses Intersections and requires O((N+K) log N) time, where K
+// is the number of intersections.
 func (p Polygon) NonIntersecting() bool {
-	side := make([]Line, len(p))
-	prev := p[len(p)-1]
+	for _, x := range Intersections(p.Segments()) {
+		for i, a := range x.Segments {
+			for _, b := range x.Segments[i+1:] {
+				if !adjacentSides(a, b, len(p)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// adjacentSides returns true if sides a and b, with a < b, of a polygon with n
+// sides share a vertex.
+func adjacentSides(a, b, n int) bool {
+	return b-a == 1 || (a == 0 && b == n-1)
+}
+
+// Near returns true if both polygons have the same number of vertexes and each
+// vertex is within eps of the vertex at the same index in p2.
+func (p Polygon) Near(p2 Polygon, eps float64) bool {
+	if len(p) != len(p2) {
+		return false
+	}
 	for i, f := range p {
-		side[i] = prev.LineTo(f)
-		prev = f
+		if !f.Near(p2[i], eps) {
+			return false
+		}
 	}
-	// Each side needs to be check against each non-adjacent side with a greater
-	// index.
-	for i, si := range side[:len(side)-2] {
-		for j, sj := range side[i+2:] {
-			if i == 0 && j == len(side)-3 {
-				// Do not check if first line segment intersects last line segment
-				continue
+	return true
+}
+
+// Equivalent returns true if p and p2 describe the same polygon within eps. The
+// polygons are equivalent if the vertexes match after rotating the starting
+// index and optionally reversing the order.
+func (p Polygon) Equivalent(p2 Polygon, eps float64) bool {
+	ln := len(p)
+	if ln != len(p2) {
+		return false
+	}
+	if ln == 0 {
+		return true
+	}
+	for start := range p2 {
+		if !p[0].Near(p2[start], eps) {
+			continue
+		}
+		for _, dir := range []int{1, ln - 1} {
+			match := true
+			for i := 1; match && i < ln; i++ {
+				match = p[i].Near(p2[(start+i*dir)%ln], eps)
 			}
-			t, _ := si.Intersection(sj)
-			if !math.IsNaN(t) && t > 0 && t < 1.0 {
-				return false
+			if match {
+				return true
 			}
 		}
 	}
-	return true
+	return false
 }
 
 // Reverse the order of the points defining the polygon
@@ -2,6 +2,7 @@ package vec2d
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -187,4 +188,105 @@ func TestFindTriangles(t *testing.T) {
 		{0, 1, 3},
 	}
 	assert.Equal(t, expected, p.FindTriangles())
+
+	// vertices along a straight side must not produce a triangle with no area
+	p = Polygon{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}}
+	ts := GetTriangles(p.FindTriangles(), p)
+	assert.Len(t, ts, 3)
+	var area float64
+	for _, tri := range ts {
+		assert.True(t, tri.Area() > 0, tri)
+		area += tri.Area()
+	}
+	assert.Equal(t, p.Area(), area)
+}
+
+func TestPolygonEquivalent(t *testing.T) {
+	p := Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	assert.True(t, p.Near(p, 0))
+	assert.True(t, p.Equivalent(Polygon{{1, 1}, {0, 1}, {0, 0}, {1, 0}}, 0))
+	assert.True(t, p.Equivalent(p.Reverse(), 0))
+	assert.True(t, p.Equivalent(Polygon{{1, 0}, {0, 0}, {0, 1}, {1, 1 + 1e-12}}, Epsilon))
+	assert.False(t, p.Near(Polygon{{1, 1}, {0, 1}, {0, 0}, {1, 0}}, Epsilon))
+	assert.False(t, p.Equivalent(Polygon{{0, 0}, {1, 1}, {1, 0}, {0, 1}}, Epsilon))
+	assert.False(t, p.Equivalent(p[:3], Epsilon))
+}
+
+func TestPolygonSeq(t *testing.T) {
+	p := Polygon{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	var vs []F
+	for i, f := range p.Vertices() {
+		assert.Equal(t, len(vs), i)
+		vs = append(vs, f)
+	}
+	assert.Equal(t, []F(p), vs)
+
+	var perimeter float64
+	for i, l := range p.Edges() {
+		assert.Equal(t, p[i], l(0))
+		assert.Equal(t, p[(i+1)%len(p)], l(1))
+		perimeter += l(0).Distance(l(1))
+	}
+	assert.Equal(t, p.Perimeter(), perimeter)
+}
+
+func TestPolygonIntersects(t *testing.T) {
+	p := Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	lineT, idx, sideT := p.Intersects(F{2, 2}.LineTo(F{6, 2}))
+	assert.Equal(t, 0.5, lineT)
+	assert.Equal(t, 1, idx)
+	assert.Equal(t, 0.5, sideT)
+
+	// the side from the last vertex back to the first is checked
+	lineT, idx, sideT = p.Intersects(F{2, 1}.LineTo(F{-2, 3}))
+	assert.Equal(t, 0.5, lineT)
+	assert.Equal(t, 3, idx)
+	assert.Equal(t, 0.5, sideT)
+
+	// the closest intersection to the start of the line is returned
+	lineT, idx, _ = p.Intersects(F{-2, 1}.LineTo(F{6, 1}))
+	assert.Equal(t, 0.25, lineT)
+	assert.Equal(t, 3, idx)
+
+	lineT, idx, sideT = p.Intersects(F{1, 1}.LineTo(F{2, 2}))
+	assert.True(t, math.IsNaN(lineT))
+	assert.Equal(t, -1, idx)
+	assert.True(t, math.IsNaN(sideT))
+
+	// touching a side at the end of the line or at a vertex is not a crossing
+	lineT, idx, _ = p.Intersects(F{2, 2}.LineTo(F{4, 2}))
+	assert.True(t, math.IsNaN(lineT))
+	assert.Equal(t, -1, idx)
+	_, idx, _ = p.Intersects(F{2, 2}.LineTo(F{6, 6}))
+	assert.Equal(t, -1, idx)
+}
+
+func TestPolygonIntersectsSegment(t *testing.T) {
+	p := Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	x, idx := p.IntersectsSegment(Segment{{2, 2}, {6, 2}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 0.5, x.T)
+	assert.Equal(t, 1, idx)
+
+	// the end of the segment on a side is included
+	x, idx = p.IntersectsSegment(Segment{{2, 2}, {4, 2}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 1.0, x.T)
+	assert.Equal(t, 0.5, x.U)
+	assert.Equal(t, 1, idx)
+
+	// passing through a vertex touches both sides, the first is returned
+	x, idx = p.IntersectsSegment(Segment{{2, 2}, {6, 6}})
+	assert.Equal(t, F{4, 4}, x.Point)
+	assert.Equal(t, 1, idx)
+
+	// overlapping a side returns the start of the overlap
+	x, idx = p.IntersectsSegment(Segment{{4, 2}, {4, 6}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.Equal(t, 0.0, x.T)
+	assert.Equal(t, 1, idx)
+
+	x, idx = p.IntersectsSegment(Segment{{1, 1}, {2, 2}})
+	assert.Equal(t, Intersection{}, x)
+	assert.Equal(t, -1, idx)
 }
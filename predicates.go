@@ -0,0 +1,162 @@
+package vec2d
+
+import (
+	"math"
+	"math/big"
+)
+
+// The predicates follow the approach described by Jonathan Shewchuk in
+// "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric
+// Predicates". The determinant is first computed with float64 and if the
+// result is larger than the worst case rounding error it is returned
+// directly. Otherwise the determinant is computed exactly. Only the sign of
+// the returned value is guaranteed to be exact.
+
+const (
+	// epsilon is half the distance from 1.0 to the next float64.
+	epsilon       = 1.0 / (1 << 53)
+	ccwErrBoundA  = (3 + 16*epsilon) * epsilon
+	iccErrBoundA  = (10 + 96*epsilon) * epsilon
+	smallestFloat = math.SmallestNonzeroFloat64
+)
+
+// Orient returns a positive value if a, b and c proceed counter-clockwise, a
+// negative value if they proceed clockwise and zero if they are collinear.
+// The magnitude is approximately twice the signed area of the triangle, but
+// the sign is always exact.
+func Orient(a, b, c F) float64 {
+	return crossDiff(c, a, c, b)
+}
+
+// crossDiff returns the cross product of (a1 - a0) and (b1 - b0) with an exact
+// sign.
+func crossDiff(a0, a1, b0, b1 F) float64 {
+	l := (a1.X - a0.X) * (b1.Y - b0.Y)
+	r := (a1.Y - a0.Y) * (b1.X - b0.X)
+	det := l - r
+	bound := ccwErrBoundA * (math.Abs(l) + math.Abs(r))
+	if det > bound || -det > bound {
+		return det
+	}
+	if !finite(a0) || !finite(a1) || !finite(b0) || !finite(b1) {
+		// big.Rat cannot represent NaN or Inf
+		return det
+	}
+	return crossDiffExact(a0, a1, b0, b1)
+}
+
+func crossDiffExact(a0, a1, b0, b1 F) float64 {
+	ax := ratSub(a1.X, a0.X)
+	ay := ratSub(a1.Y, a0.Y)
+	bx := ratSub(b1.X, b0.X)
+	by := ratSub(b1.Y, b0.Y)
+	l := new(big.Rat).Mul(ax, by)
+	r := new(big.Rat).Mul(ay, bx)
+	return ratFloat(l.Sub(l, r))
+}
+
+// InCircle returns a positive value if d lies inside the circle passing
+// through a, b and c, a negative value if it lies outside and zero if it lies
+// on the circle. The points a, b and c must proceed counter-clockwise or the
+// sign is reversed. Only the sign of the returned value is guaranteed to be
+// exact.
+func InCircle(a, b, c, d F) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	alift := adx*adx + ady*ady
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	blift := bdx*bdx + bdy*bdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) +
+		blift*(cdxady-adxcdy) +
+		clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	bound := iccErrBoundA * permanent
+	if det > bound || -det > bound {
+		return det
+	}
+	if !finite(a) || !finite(b) || !finite(c) || !finite(d) {
+		return det
+	}
+	return inCircleExact(a, b, c, d)
+}
+
+func inCircleExact(a, b, c, d F) float64 {
+	adx, ady := ratSub(a.X, d.X), ratSub(a.Y, d.Y)
+	bdx, bdy := ratSub(b.X, d.X), ratSub(b.Y, d.Y)
+	cdx, cdy := ratSub(c.X, d.X), ratSub(c.Y, d.Y)
+
+	lift := func(x, y *big.Rat) *big.Rat {
+		l := new(big.Rat).Mul(x, x)
+		return l.Add(l, new(big.Rat).Mul(y, y))
+	}
+	cross := func(ax, ay, bx, by *big.Rat) *big.Rat {
+		l := new(big.Rat).Mul(ax, by)
+		return l.Sub(l, new(big.Rat).Mul(ay, bx))
+	}
+
+	det := new(big.Rat).Mul(lift(adx, ady), cross(bdx, bdy, cdx, cdy))
+	det.Add(det, new(big.Rat).Mul(lift(bdx, bdy), cross(cdx, cdy, adx, ady)))
+	det.Add(det, new(big.Rat).Mul(lift(cdx, cdy), cross(adx, ady, bdx, bdy)))
+	return ratFloat(det)
+}
+
+// finite returns false if either value of f is NaN or infinite. The exact
+// predicates can only be computed when all of the values are finite.
+func finite(f F) bool {
+	return !math.IsNaN(f.X) && !math.IsInf(f.X, 0) && !math.IsNaN(f.Y) && !math.IsInf(f.Y, 0)
+}
+
+func ratSub(a, b float64) *big.Rat {
+	r := new(big.Rat).SetFloat64(a)
+	return r.Sub(r, new(big.Rat).SetFloat64(b))
+}
+
+// ratFloat converts r to a float64, making sure that a non-zero value does not
+// underflow to zero.
+func ratFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	if f == 0 {
+		return float64(r.Sign()) * smallestFloat
+	}
+	return f
+}
+
+// sign returns -1, 0 or 1
+func sign(f float64) int {
+	if f > 0 {
+		return 1
+	}
+	if f < 0 {
+		return -1
+	}
+	return 0
+}
+
+// segmentsTouch returns true if the closed segments a0 to a1 and b0 to b1 share
+// at least one point. Collinear segments touch if they overlap.
+func segmentsTouch(a0, a1, b0, b1 F) bool {
+	o1, o2 := sign(Orient(a0, a1, b0)), sign(Orient(a0, a1, b1))
+	if o1*o2 > 0 {
+		return false
+	}
+	o3, o4 := sign(Orient(b0, b1, a0)), sign(Orient(b0, b1, a1))
+	if o3*o4 > 0 {
+		return false
+	}
+	if o1 == 0 && o2 == 0 {
+		// collinear, they touch if their bounding boxes overlap
+		aMin, aMax := a0.Min(a1), a0.Max(a1)
+		bMin, bMax := b0.Min(b1), b0.Max(b1)
+		return aMin.X <= bMax.X && bMin.X <= aMax.X &&
+			aMin.Y <= bMax.Y && bMin.Y <= aMax.Y
+	}
+	return true
+}
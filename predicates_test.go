@@ -0,0 +1,113 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestOrient(t *testing.T) {
+	assert.True(t, Orient(F{0, 0}, F{1, 0}, F{0, 1}) > 0)
+	assert.True(t, Orient(F{0, 0}, F{0, 1}, F{1, 0}) < 0)
+	assert.Equal(t, 0.0, Orient(F{0, 0}, F{1, 1}, F{3, 3}))
+
+	// Naive float64 evaluation gets the sign of these wrong. Each point is
+	// nudged by a single ulp along the line y=x.
+	a := F{0.5, 0.5}
+	b := F{12, 12}
+	c := F{24, 24}
+	ulp := math.Nextafter(a.X, 1) - a.X
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			p := F{
+				a.X + float64(i)*ulp,
+				a.Y + float64(j)*ulp,
+			}
+			o := Orient(p, b, c)
+			// exact answer is determined by comparing p.X and p.Y since b and c
+			// lie on y=x
+			switch {
+			case p.X > p.Y:
+				assert.True(t, o < 0, p)
+			case p.X < p.Y:
+				assert.True(t, o > 0, p)
+			default:
+				assert.Equal(t, 0.0, o, p)
+			}
+			// permutations must agree
+			assert.Equal(t, sign(o), sign(Orient(b, c, p)))
+			assert.Equal(t, sign(o), -sign(Orient(c, b, p)))
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	a, b, c := F{0, 0}, F{1, 0}, F{0, 1}
+	assert.True(t, InCircle(a, b, c, F{0.5, 0.5}) > 0)
+	assert.True(t, InCircle(a, b, c, F{2, 2}) < 0)
+	assert.Equal(t, 0.0, InCircle(a, b, c, F{1, 1}))
+	assert.True(t, InCircle(a, c, b, F{0.5, 0.5}) < 0)
+
+	// d lies just outside of the circle by the smallest representable amount
+	d := F{math.Nextafter(1, 2), 1}
+	assert.True(t, InCircle(a, b, c, d) < 0)
+	d = F{math.Nextafter(1, 0), 1}
+	assert.True(t, InCircle(a, b, c, d) > 0)
+}
+
+func TestSegmentsTouch(t *testing.T) {
+	tt := []struct {
+		a0, a1, b0, b1 F
+		expected       bool
+	}{
+		{F{0, 0}, F{2, 2}, F{0, 2}, F{2, 0}, true},
+		{F{0, 0}, F{1, 1}, F{0, 2}, F{2, 3}, false},
+		{F{0, 0}, F{2, 0}, F{1, 0}, F{1, 1}, true},
+		{F{0, 0}, F{2, 0}, F{1, 3}, F{3, 0}, false},
+		{F{0, 0}, F{2, 0}, F{1, 0}, F{3, 0}, true},
+		{F{0, 0}, F{2, 0}, F{3, 0}, F{4, 0}, false},
+		{F{0, 0}, F{0, 2}, F{0, 2}, F{0, 4}, true},
+		{F{1, 1}, F{1, 1}, F{0, 0}, F{2, 2}, true},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.expected, segmentsTouch(tc.a0, tc.a1, tc.b0, tc.b1), tc)
+		assert.Equal(t, tc.expected, segmentsTouch(tc.b1, tc.b0, tc.a0, tc.a1), tc)
+	}
+}
+
+func TestRobustContains(t *testing.T) {
+	// Adjacent triangles sharing a nearly degenerate diagonal should not both
+	// claim points on opposite sides of it.
+	a, b := F{0.1, 0.1}, F{0.3, 0.7}
+	t1 := Triangle{a, b, F{0, 1}}
+	t2 := Triangle{a, F{1, 0}, b}
+	ln := a.LineTo(b)
+	for i := 1; i < 100; i++ {
+		f := ln(float64(i) / 100)
+		assert.True(t, t1.Contains(f) || t2.Contains(f))
+		o := Orient(a, b, f)
+		if o > 0 {
+			assert.False(t, t2.Contains(f), f)
+		} else if o < 0 {
+			assert.False(t, t1.Contains(f), f)
+		}
+	}
+}
+
+func TestPredicatesNotFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	assert.True(t, math.IsNaN(Orient(F{0, 0}, F{1, 0}, F{nan, 0})))
+	assert.True(t, math.IsNaN(Orient(F{0, 0}, F{1, 0}, F{inf, 1})))
+	assert.Equal(t, -inf, Orient(F{0, 0}, F{0, inf}, F{1, 0}))
+	assert.True(t, math.IsNaN(InCircle(F{0, 0}, F{1, 0}, F{0, 1}, F{nan, 0})))
+	assert.True(t, math.IsNaN(InCircle(F{0, 0}, F{1, 0}, F{0, 1}, F{-inf, 0})))
+	assert.NotPanics(t, func() { segmentsTouch(F{0, 0}, F{1, 0}, F{nan, nan}, F{0, 1}) })
+
+	assert.False(t, Triangle{{0, 0}, {1, 0}, {0, 1}}.Contains(F{nan, 0}))
+	assert.False(t, Polygon{{0, 0}, {1, 0}, {0, 1}}.Contains(F{0, nan}))
+	i0, i1 := F{0, 0}.LineTo(F{inf, 1}).Intersection(F{0, 1}.LineTo(F{1, 1}))
+	assert.True(t, math.IsNaN(i0))
+	assert.True(t, math.IsNaN(i1))
+	assert.NotPanics(t, func() { Segment{{0, 0}, {nan, 1}}.Intersect(Segment{{0, 1}, {1, 0}}) })
+	assert.NotPanics(t, func() { Intersections([]Segment{{{0, 0}, {inf, 1}}, {{0, 1}, {1, 0}}}) })
+}
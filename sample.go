@@ -0,0 +1,121 @@
+package vec2d
+
+import (
+	"math"
+)
+
+// Sample returns n points on the curve evenly spaced in t, starting at t=0 and
+// ending at t=1. If n is 1, only the point at t=0 is returned.
+func Sample(c Curver, n int) LineSegments {
+	if n < 1 {
+		return nil
+	}
+	if n == 1 {
+		return LineSegments{c.F(0)}
+	}
+	ls := make(LineSegments, n)
+	d := float64(n - 1)
+	for i := range ls {
+		ls[i] = c.F(float64(i) / d)
+	}
+	return ls
+}
+
+// DefaultFlattenDepth is the MaxDepth used by a Flattener that does not set
+// one.
+const DefaultFlattenDepth = 16
+
+// Flattener approximates a curve with line segments, adding more segments
+// where the curve bends and fewer where it is straight. Each segment is split
+// in half until it is within both tolerances. A tolerance of 0 is not checked,
+// so the zero value only produces the MinSegments uniform segments.
+type Flattener struct {
+	// Deviation is the maximum distance allowed between the curve and the
+	// segment that approximates it.
+	Deviation float64
+	// Angle is the maximum angle, in radians, that the curve may turn within a
+	// single segment, which is also the greatest angle between consecutive
+	// segments.
+	Angle float64
+	// MinSegments is the number of uniform segments the curve is divided into
+	// before any are split. Increasing it prevents small features that fall
+	// between the samples of a segment from being missed. Values less than 1
+	// are treated as 1.
+	MinSegments int
+	// MaxDepth limits how many times a segment can be split in half. If it is
+	// 0, DefaultFlattenDepth is used.
+	MaxDepth int
+}
+
+// FlattenDeviation approximates c with line segments that are no more than
+// deviation from the curve. See Flattener.
+func FlattenDeviation(c Curver, deviation float64) (LineSegments, []float64) {
+	return Flattener{Deviation: deviation}.Flatten(c)
+}
+
+// FlattenAngle approximates c with line segments so that the curve turns no
+// more than angle radians within any segment. See Flattener.
+func FlattenAngle(c Curver, angle float64) (LineSegments, []float64) {
+	return Flattener{Angle: angle}.Flatten(c)
+}
+
+// Flatten returns the line segments approximating c and the value of t used
+// for each point. The first point is at t=0 and the last is at t=1.
+func (f Flattener) Flatten(c Curver) (LineSegments, []float64) {
+	n := f.MinSegments
+	if n < 1 {
+		n = 1
+	}
+	depth := f.MaxDepth
+	if depth == 0 {
+		depth = DefaultFlattenDepth
+	}
+
+	t0, p0 := 0.0, c.F(0)
+	ls := LineSegments{p0}
+	ts := []float64{t0}
+	for i := 1; i <= n; i++ {
+		t1 := float64(i) / float64(n)
+		p1 := c.F(t1)
+		ls, ts = f.subdivide(c, t0, t1, p0, p1, depth, ls, ts)
+		t0, p0 = t1, p1
+	}
+	return ls, ts
+}
+
+// subdivide appends the points after p0 up to and including p1.
+func (f Flattener) subdivide(c Curver, t0, t1 float64, p0, p1 F, depth int, ls LineSegments, ts []float64) (LineSegments, []float64) {
+	tm := (t0 + t1) / 2
+	pm := c.F(tm)
+	if depth > 0 && !f.flat(c, t0, t1, p0, pm, p1) {
+		ls, ts = f.subdivide(c, t0, tm, p0, pm, depth-1, ls, ts)
+		return f.subdivide(c, tm, t1, pm, p1, depth-1, ls, ts)
+	}
+	return append(ls, p1), append(ts, t1)
+}
+
+// flat checks if the curve between t0 and t1 is within the tolerances of the
+// segment from p0 to p1. Along with the mid point pm, the quarter points are
+// checked for deviation so an S shaped curve that crosses the segment at its
+// middle is not mistaken for a straight one.
+func (f Flattener) flat(c Curver, t0, t1 float64, p0, pm, p1 F) bool {
+	// the turn between the two halves is about half the turn of the curve
+	if f.Angle > 0 && 2*math.Abs(pm.Subtract(p0).AngleTo(p1.Subtract(pm))) > f.Angle {
+		return false
+	}
+	if f.Deviation > 0 {
+		d := t1 - t0
+		for _, pt := range []F{c.F(t0 + d/4), pm, c.F(t1 - d/4)} {
+			if segmentDistance(pt, p0, p1) > f.Deviation {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// segmentDistance returns the distance from pt to the closest point on the
+// segment from a to b.
+func segmentDistance(pt, a, b F) float64 {
+	return pt.Distance(a.LineTo(b).ClosestOnSegment(pt))
+}
@@ -0,0 +1,92 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	l := F{0, 0}.LineTo(F{4, 2})
+	assert.Equal(t, LineSegments{{0, 0}, {1, 0.5}, {2, 1}, {3, 1.5}, {4, 2}}, Sample(l, 5))
+	assert.Equal(t, LineSegments{{0, 0}}, Sample(l, 1))
+	assert.Nil(t, Sample(l, 0))
+
+	c := NewBezierCurve(F{0, 0}, F{0.5, 1}, F{1, 0})
+	assert.Equal(t, LineSegments{{0, 0}, {0.5, 0.5}, {1, 0}}, Sample(c, 3))
+}
+
+// checkFlat confirms the points and t values agree and that the curve stays
+// within deviation of the segments.
+func checkFlat(t *testing.T, c Curver, ls LineSegments, ts []float64, deviation float64) {
+	if !assert.Equal(t, len(ls), len(ts)) {
+		return
+	}
+	assert.Equal(t, 0.0, ts[0])
+	assert.Equal(t, 1.0, ts[len(ts)-1])
+	for i, pt := range ls {
+		assert.Equal(t, c.F(ts[i]), pt)
+		if i == 0 {
+			continue
+		}
+		assert.True(t, ts[i] > ts[i-1])
+		for s := 1; s < 10; s++ {
+			tm := ts[i-1] + (ts[i]-ts[i-1])*float64(s)/10
+			assert.True(t, segmentDistance(c.F(tm), ls[i-1], pt) <= deviation*1.01)
+		}
+	}
+}
+
+func TestFlattenDeviation(t *testing.T) {
+	// a straight curve needs only one segment
+	straight := NewBezierPath(F{0, 0}, F{1, 1}, F{2, 2}, F{3, 3})
+	ls, ts := FlattenDeviation(straight, 0.01)
+	assert.Equal(t, LineSegments{{0, 0}, {3, 3}}, ls)
+	assert.Equal(t, []float64{0, 1}, ts)
+
+	circle := NewCircle(F{1, 2}, 10).Arc()
+	for _, d := range []float64{1, 0.1, 0.01} {
+		ls, ts = FlattenDeviation(circle, d)
+		checkFlat(t, circle, ls, ts, d)
+	}
+	coarse, _ := FlattenDeviation(circle, 0.1)
+	fine, _ := FlattenDeviation(circle, 0.01)
+	assert.True(t, len(fine) > len(coarse))
+
+	// the S curve crosses the chord at its midpoint
+	s := NewBezierPath(F{0, 0}, F{1, 3}, F{2, -3}, F{3, 0})
+	ls, ts = FlattenDeviation(s, 0.05)
+	assert.True(t, len(ls) > 2)
+	checkFlat(t, s, ls, ts, 0.05)
+
+	// points are concentrated where the curve bends
+	bend := NewBezierPath(F{0, 0}, F{10, 0}, F{10, 0}, F{10, 10})
+	ls, ts = FlattenDeviation(bend, 0.01)
+	checkFlat(t, bend, ls, ts, 0.01)
+	var nearCorner int
+	for _, pt := range ls {
+		if pt.Distance(F{10, 0}) < 5 {
+			nearCorner++
+		}
+	}
+	assert.True(t, nearCorner > len(ls)/2)
+}
+
+func TestFlattenAngle(t *testing.T) {
+	circle := NewCircle(F{}, 1).Arc()
+	angle := math.Pi / 8 * 1.01
+	ls, ts := FlattenAngle(circle, angle)
+	assert.Equal(t, len(ls), len(ts))
+	assert.Len(t, ls, 17)
+	for i := 2; i < len(ls); i++ {
+		turn := ls[i-1].Subtract(ls[i-2]).AngleTo(ls[i].Subtract(ls[i-1]))
+		assert.True(t, math.Abs(turn) <= angle)
+	}
+
+	ls, ts = Flattener{MinSegments: 4}.Flatten(circle)
+	assert.Len(t, ls, 5)
+	assert.Equal(t, []float64{0, 0.25, 0.5, 0.75, 1}, ts)
+
+	ls, _ = Flattener{Deviation: 1e-9, MaxDepth: 3}.Flatten(circle)
+	assert.Len(t, ls, 9)
+}
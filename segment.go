@@ -0,0 +1,239 @@
+package vec2d
+
+import (
+	"math"
+)
+
+// Linear is fulfilled by Segment, Ray and InfiniteLine. Each describes a line
+// through two points, where the first point is at t=0 and the second is at t=1,
+// and the range of t that is part of the shape.
+type Linear interface {
+	// Points returns the points at t=0 and t=1.
+	Points() (F, F)
+	// Bounds returns the least and greatest value of t that is part of the
+	// shape. They may be infinite.
+	Bounds() (t0, t1 float64)
+	// Line returns the Line that passes through both points.
+	Line() Line
+}
+
+// Segment is the part of a line between two points, including both points. On
+// the segment t ranges from 0 at the first point to 1 at the second point.
+type Segment [2]F
+
+// Points returns the ends of the segment. Fulfills Linear.
+func (s Segment) Points() (F, F) { return s[0], s[1] }
+
+// Bounds returns 0 and 1. Fulfills Linear.
+func (s Segment) Bounds() (float64, float64) { return 0, 1 }
+
+// Line returns the line from the first point to the second. Fulfills Linear.
+func (s Segment) Line() Line { return s[0].LineTo(s[1]) }
+
+// F returns the point at t. Fulfills Curver.
+func (s Segment) F(t float64) F { return s.Line()(t) }
+
+// Intersect finds where the segment intersects l. See Intersection.
+func (s Segment) Intersect(l Linear) Intersection { return intersect(s, l) }
+
+// Segment returns the part of the line between t=0 and t=1.
+func (l Line) Segment() Segment { return Segment{l(0), l(1)} }
+
+// Ray starts at Origin and extends forever in Direction. On the ray t ranges
+// from 0 at the Origin to infinity, with Origin+Direction at t=1.
+type Ray struct {
+	Origin, Direction F
+}
+
+// Points returns Origin and Origin+Direction. Fulfills Linear.
+func (r Ray) Points() (F, F) { return r.Origin, r.Origin.Add(r.Direction) }
+
+// Bounds returns 0 and +Inf. Fulfills Linear.
+func (r Ray) Bounds() (float64, float64) { return 0, math.Inf(1) }
+
+// Line returns the line from Origin through Origin+Direction. Fulfills Linear.
+func (r Ray) Line() Line { return r.Origin.LineTo(r.Origin.Add(r.Direction)) }
+
+// F returns the point at t. Fulfills Curver.
+func (r Ray) F(t float64) F { return r.Origin.Add(r.Direction.ScalarMultiply(t)) }
+
+// Intersect finds where the ray intersects l. See Intersection.
+func (r Ray) Intersect(l Linear) Intersection { return intersect(r, l) }
+
+// Ray returns the ray that starts at t=0 and passes through t=1.
+func (l Line) Ray() Ray {
+	o := l(0)
+	return Ray{o, l(1).Subtract(o)}
+}
+
+// InfiniteLine passes through Point and extends forever in Direction and in
+// the opposite direction. On the line Point is at t=0 and Point+Direction is at
+// t=1.
+type InfiniteLine struct {
+	Point, Direction F
+}
+
+// Points returns Point and Point+Direction. Fulfills Linear.
+func (il InfiniteLine) Points() (F, F) { return il.Point, il.Point.Add(il.Direction) }
+
+// Bounds returns -Inf and +Inf. Fulfills Linear.
+func (il InfiniteLine) Bounds() (float64, float64) { return math.Inf(-1), math.Inf(1) }
+
+// Line returns the line from Point through Point+Direction. Fulfills Linear.
+func (il InfiniteLine) Line() Line { return il.Point.LineTo(il.Point.Add(il.Direction)) }
+
+// F returns the point at t. Fulfills Curver.
+func (il InfiniteLine) F(t float64) F { return il.Point.Add(il.Direction.ScalarMultiply(t)) }
+
+// Intersect finds where the line intersects l. See Intersection.
+func (il InfiniteLine) Intersect(l Linear) Intersection { return intersect(il, l) }
+
+// InfiniteLine returns the infinite line through the points at t=0 and t=1.
+func (l Line) InfiniteLine() InfiniteLine {
+	p := l(0)
+	return InfiniteLine{p, l(1).Subtract(p)}
+}
+
+// IntersectionKind describes how two Linear shapes intersect.
+type IntersectionKind byte
+
+const (
+	// IntersectNone means the shapes do not share any points. This includes
+	// parallel lines and collinear shapes that do not overlap.
+	IntersectNone IntersectionKind = iota
+	// IntersectPoint means the shapes share exactly one point.
+	IntersectPoint
+	// IntersectOverlap means the shapes are collinear and share more than one
+	// point.
+	IntersectOverlap
+)
+
+// String returns the name of the IntersectionKind.
+func (k IntersectionKind) String() string {
+	switch k {
+	case IntersectPoint:
+		return "Point"
+	case IntersectOverlap:
+		return "Overlap"
+	}
+	return "None"
+}
+
+// Intersection describes where two Linear shapes intersect. The bounds of each
+// shape are inclusive, so the end of a Segment or the Origin of a Ray can be
+// an intersection.
+type Intersection struct {
+	Kind IntersectionKind
+	// T is the parametric value of the intersection on the receiver and U is
+	// the parametric value of the same point on the argument. For an overlap,
+	// they are where the overlap starts.
+	T, U float64
+	// T1 and U1 are where an overlap ends, with T <= T1. They are infinite if
+	// the overlap is. For a point intersection, they are equal to T and U.
+	T1, U1 float64
+	// Point is the point of intersection. For an overlap, it is the point at T
+	// if T is finite.
+	Point F
+	// Collinear is true if both shapes lie on the same line, even if they do
+	// not share any points. Shapes that are parallel but not collinear have a
+	// Kind of IntersectNone and Collinear false. By convention, two different
+	// points are not collinear because neither has a direction to compare.
+	Collinear bool
+}
+
+// pointAt returns the point at t on the line from p0 to p1, returning the
+// points exactly at t=0 and t=1.
+func pointAt(p0, p1 F, t float64) F {
+	if t == 1 {
+		return p1
+	}
+	return p0.Add(p1.Subtract(p0).ScalarMultiply(t))
+}
+
+func intersect(a, b Linear) Intersection {
+	a0, a1 := a.Points()
+	b0, b1 := b.Points()
+	aLo, aHi := a.Bounds()
+	bLo, bHi := b.Bounds()
+	r, s := a1.Subtract(a0), b1.Subtract(b0)
+
+	if r == (F{}) {
+		if s == (F{}) {
+			if a0 == b0 {
+				return Intersection{Kind: IntersectPoint, Point: a0, Collinear: true}
+			}
+			// by convention, two different points are not reported as
+			// collinear because neither has a direction to compare
+			return Intersection{}
+		}
+		// a is a single point, find it on b
+		x := intersect(b, a)
+		x.T, x.U = x.U, x.T
+		x.T1, x.U1 = x.T, x.U
+		if x.Kind != IntersectNone {
+			x.Point = a0
+		}
+		return x
+	}
+
+	qp := b0.Subtract(a0)
+	if d := crossDiff(a0, a1, b0, b1); d != 0 {
+		t := qp.Cross(s) / d
+		u := qp.Cross(r) / d
+		if t < aLo || t > aHi || u < bLo || u > bHi {
+			return Intersection{}
+		}
+		return Intersection{
+			Kind:  IntersectPoint,
+			T:     t,
+			U:     u,
+			T1:    t,
+			U1:    u,
+			Point: pointAt(a0, a1, t),
+		}
+	}
+	if Orient(a0, a1, b0) != 0 {
+		// parallel
+		return Intersection{}
+	}
+
+	// collinear, map the bounds of b onto a
+	rr := r.Dot(r)
+	c := qp.Dot(r) / rr
+	k := s.Dot(r) / rr
+	tAt := func(u float64) float64 {
+		if k == 0 {
+			return c
+		}
+		return c + u*k
+	}
+	uAt := func(t float64) float64 {
+		if k == 0 {
+			return 0
+		}
+		return (t - c) / k
+	}
+	lo, hi := tAt(bLo), tAt(bHi)
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	lo, hi = math.Max(lo, aLo), math.Min(hi, aHi)
+	if lo > hi {
+		return Intersection{Collinear: true}
+	}
+	x := Intersection{
+		Kind:      IntersectOverlap,
+		T:         lo,
+		U:         uAt(lo),
+		T1:        hi,
+		U1:        uAt(hi),
+		Collinear: true,
+	}
+	if lo == hi {
+		x.Kind = IntersectPoint
+	}
+	if !math.IsInf(lo, 0) {
+		x.Point = pointAt(a0, a1, lo)
+	}
+	return x
+}
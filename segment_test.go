@@ -0,0 +1,137 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestSegmentIntersect(t *testing.T) {
+	s := Segment{{0, 0}, {2, 2}}
+	x := s.Intersect(Segment{{0, 2}, {2, 0}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 0.5, x.T)
+	assert.Equal(t, 0.5, x.U)
+	assert.Equal(t, F{1, 1}, x.Point)
+
+	// the ends are included
+	x = s.Intersect(Segment{{2, 2}, {3, 0}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 1.0, x.T)
+	assert.Equal(t, 0.0, x.U)
+	assert.Equal(t, F{2, 2}, x.Point)
+
+	// the lines cross outside the segment
+	assert.Equal(t, IntersectNone, s.Intersect(Segment{{3, 0}, {4, 1}}).Kind)
+
+	// parallel
+	assert.Equal(t, IntersectNone, s.Intersect(Segment{{1, 0}, {3, 2}}).Kind)
+
+	// collinear without overlap
+	assert.Equal(t, IntersectNone, s.Intersect(Segment{{3, 3}, {4, 4}}).Kind)
+
+	// collinear touching at one end
+	x = s.Intersect(Segment{{3, 3}, {2, 2}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 1.0, x.T)
+	assert.Equal(t, 1.0, x.U)
+
+	// overlap, reversed direction
+	x = s.Intersect(Segment{{3, 3}, {1, 1}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.Equal(t, 0.5, x.T)
+	assert.Equal(t, 1.0, x.U)
+	assert.Equal(t, 1.0, x.T1)
+	assert.Equal(t, 0.5, x.U1)
+	assert.Equal(t, F{1, 1}, x.Point)
+
+	// a zero length segment is a point
+	x = s.Intersect(Segment{{0.5, 0.5}, {0.5, 0.5}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 0.25, x.T)
+	assert.Equal(t, F{0.5, 0.5}, x.Point)
+	x = Segment{{0.5, 0.5}, {0.5, 0.5}}.Intersect(s)
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 0.25, x.U)
+	assert.Equal(t, IntersectNone, Segment{{1, 0}, {1, 0}}.Intersect(s).Kind)
+
+	// two zero length segments intersect only if they are the same point
+	p := Segment{{1, 2}, {1, 2}}
+	x = p.Intersect(p)
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, F{1, 2}, x.Point)
+	assert.Equal(t, Intersection{}, p.Intersect(Segment{{3, 4}, {3, 4}}))
+}
+
+func TestRayIntersect(t *testing.T) {
+	r := Ray{F{0, 0}, F{1, 0}}
+	x := r.Intersect(Segment{{5, -1}, {5, 1}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 5.0, x.T)
+	assert.Equal(t, F{5, 0}, x.Point)
+
+	// behind the origin
+	assert.Equal(t, IntersectNone, r.Intersect(Segment{{-5, -1}, {-5, 1}}).Kind)
+
+	// opposing rays overlap between their origins
+	x = r.Intersect(Ray{F{4, 0}, F{-2, 0}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.Equal(t, 0.0, x.T)
+	assert.Equal(t, 4.0, x.T1)
+	assert.Equal(t, 2.0, x.U)
+	assert.Equal(t, 0.0, x.U1)
+
+	// rays in the same direction overlap forever
+	x = r.Intersect(Ray{F{4, 0}, F{2, 0}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.Equal(t, 4.0, x.T)
+	assert.True(t, math.IsInf(x.T1, 1))
+	assert.Equal(t, F{4, 0}, x.Point)
+
+	// rays pointing away from each other share only the origin
+	x = r.Intersect(Ray{F{0, 0}, F{-1, 0}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, F{0, 0}, x.Point)
+}
+
+func TestInfiniteLineIntersect(t *testing.T) {
+	l := InfiniteLine{F{0, 1}, F{1, 0}}
+	x := l.Intersect(Ray{F{3, 5}, F{0, 1}})
+	assert.Equal(t, IntersectNone, x.Kind)
+
+	x = l.Intersect(InfiniteLine{F{3, 5}, F{0, 1}})
+	assert.Equal(t, IntersectPoint, x.Kind)
+	assert.Equal(t, 3.0, x.T)
+	assert.Equal(t, -4.0, x.U)
+	assert.Equal(t, F{3, 1}, x.Point)
+
+	x = l.Intersect(InfiniteLine{F{3, 1}, F{-2, 0}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.True(t, math.IsInf(x.T, -1))
+	assert.True(t, math.IsInf(x.T1, 1))
+
+	x = l.Intersect(Segment{{-1, 1}, {-3, 1}})
+	assert.Equal(t, IntersectOverlap, x.Kind)
+	assert.Equal(t, -3.0, x.T)
+	assert.Equal(t, -1.0, x.T1)
+	assert.Equal(t, 1.0, x.U)
+	assert.Equal(t, 0.0, x.U1)
+	assert.Equal(t, F{-3, 1}, x.Point)
+
+	assert.Equal(t, IntersectNone, l.Intersect(InfiniteLine{F{0, 0}, F{-1, 0}}).Kind)
+}
+
+func TestLinearConversion(t *testing.T) {
+	l := F{1, 2}.LineTo(F{4, 6})
+	assert.Equal(t, Segment{{1, 2}, {4, 6}}, l.Segment())
+	assert.Equal(t, Ray{F{1, 2}, F{3, 4}}, l.Ray())
+	assert.Equal(t, InfiniteLine{F{1, 2}, F{3, 4}}, l.InfiniteLine())
+
+	for _, lin := range []Linear{l.Segment(), l.Ray(), l.InfiniteLine()} {
+		for _, tt := range []float64{0, 0.5, 1, 2} {
+			assert.Equal(t, l(tt), lin.Line()(tt))
+			assert.Equal(t, l(tt), lin.(Curver).F(tt))
+		}
+	}
+	assert.Equal(t, "Overlap", IntersectOverlap.String())
+}
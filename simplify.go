@@ -0,0 +1,350 @@
+package vec2d
+
+import (
+	"container/heap"
+	"math"
+)
+
+// SimplifyRDP removes points using the Ramer–Douglas–Peucker algorithm. Each
+// removed point is within epsilon of the segment that replaces it. The first
+// and last points are always kept. If keepSimple is true, removed points are
+// restored where the simplified segments would intersect each other.
+func (ls LineSegments) SimplifyRDP(epsilon float64, keepSimple bool) LineSegments {
+	if len(ls) < 3 {
+		return append(LineSegments(nil), ls...)
+	}
+	keep := make([]bool, len(ls))
+	keep[0], keep[len(ls)-1] = true, true
+	rdp(ls, keep, 0, len(ls)-1, epsilon)
+	return ls.simplified(keep, keepSimple)
+}
+
+// SimplifyVW removes points using the Visvalingam–Whyatt algorithm. The point
+// that forms the smallest triangle with its neighbors is removed until every
+// remaining triangle has an area of at least area. The first and last points
+// are always kept. If keepSimple is true, removed points are restored where
+// the simplified segments would intersect each other.
+func (ls LineSegments) SimplifyVW(area float64, keepSimple bool) LineSegments {
+	if len(ls) < 3 {
+		return append(LineSegments(nil), ls...)
+	}
+	return ls.simplified(visvalingam(ls, area, false), keepSimple)
+}
+
+// SimplifyRadial removes each point that is within dist of the last point that
+// was kept. The first and last points are always kept. This is much faster
+// than the other methods but only removes points that are close together, so
+// it is often used to reduce the input to one of them. If keepSimple is true,
+// removed points are restored where the simplified segments would intersect
+// each other.
+func (ls LineSegments) SimplifyRadial(dist float64, keepSimple bool) LineSegments {
+	if len(ls) < 3 {
+		return append(LineSegments(nil), ls...)
+	}
+	keep := radial(ls, dist)
+	keep[len(ls)-1] = true
+	return ls.simplified(keep, keepSimple)
+}
+
+// simplified returns the points of ls that are kept, after restoring points
+// to prevent segments from intersecting if keepSimple is true.
+func (ls LineSegments) simplified(keep []bool, keepSimple bool) LineSegments {
+	if keepSimple {
+		for restoreCrossing(ls, keep, false) {
+		}
+	}
+	return LineSegments(kept(ls, keep))
+}
+
+// SimplifyRDP removes vertexes using the Ramer–Douglas–Peucker algorithm,
+// treating the polygon as closed. The result has at least 3 vertexes if p
+// does. If keepSimple is true, removed vertexes are restored where the
+// simplified polygon would intersect itself. See LineSegments.SimplifyRDP.
+func (p Polygon) SimplifyRDP(epsilon float64, keepSimple bool) Polygon {
+	if len(p) < 4 {
+		return append(Polygon(nil), p...)
+	}
+	// split the polygon into two chains between the first vertex and the
+	// vertex farthest from it
+	far, d := 0, 0.0
+	for i, f := range p {
+		if fd := f.Distance(p[0]); fd > d {
+			far, d = i, fd
+		}
+	}
+	closed := append(p[:len(p):len(p)], p[0])
+	keep := make([]bool, len(closed))
+	keep[0], keep[far] = true, true
+	if far > 0 {
+		rdp(closed, keep, 0, far, epsilon)
+		rdp(closed, keep, far, len(p), epsilon)
+	}
+	return p.simplified(keep[:len(p)], keepSimple)
+}
+
+// SimplifyVW removes vertexes using the Visvalingam–Whyatt algorithm,
+// treating the polygon as closed. The result has at least 3 vertexes if p
+// does. If keepSimple is true, removed vertexes are restored where the
+// simplified polygon would intersect itself. See LineSegments.SimplifyVW.
+func (p Polygon) SimplifyVW(area float64, keepSimple bool) Polygon {
+	if len(p) < 4 {
+		return append(Polygon(nil), p...)
+	}
+	return p.simplified(visvalingam(p, area, true), keepSimple)
+}
+
+// SimplifyRadial removes each vertex that is within dist of the last vertex
+// that was kept or of the first vertex. The result has at least 3 vertexes if
+// p does. If keepSimple is true, removed vertexes are restored where the
+// simplified polygon would intersect itself. See LineSegments.SimplifyRadial.
+func (p Polygon) SimplifyRadial(dist float64, keepSimple bool) Polygon {
+	if len(p) < 4 {
+		return append(Polygon(nil), p...)
+	}
+	keep := radial(p, dist)
+	// the polygon returns to the first vertex
+	for i := len(p) - 1; i > 0; i-- {
+		if keep[i] {
+			if p[i].Distance(p[0]) > dist {
+				break
+			}
+			keep[i] = false
+		}
+	}
+	return p.simplified(keep, keepSimple)
+}
+
+// simplified returns the vertexes of p that are kept, after restoring enough
+// vertexes to have at least 3 and, if keepSimple is true, to prevent sides
+// from intersecting.
+func (p Polygon) simplified(keep []bool, keepSimple bool) Polygon {
+	for countKept(keep) < 3 {
+		p.restoreFarthest(keep)
+	}
+	if keepSimple {
+		for restoreCrossing(p, keep, true) {
+		}
+	}
+	return Polygon(kept(p, keep))
+}
+
+// restoreFarthest restores the removed vertex farthest from the segment
+// between the first and last vertexes that are kept.
+func (p Polygon) restoreFarthest(keep []bool) {
+	first, last := -1, -1
+	for i, k := range keep {
+		if k {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+	best, d := -1, -1.0
+	for i, f := range p {
+		if fd := segmentDistance(f, p[first], p[last]); !keep[i] && fd > d {
+			best, d = i, fd
+		}
+	}
+	keep[best] = true
+}
+
+// restoreCrossing finds the segments between the kept points that intersect
+// a segment they are not adjacent to. If closed is true, the last kept point
+// connects back to the first. On each of those segments the removed point
+// farthest from the segment is restored. It returns false if no points were
+// restored.
+func restoreCrossing(pts []F, keep []bool, closed bool) bool {
+	idx := make([]int, 0, len(pts))
+	for i, k := range keep {
+		if k {
+			idx = append(idx, i)
+		}
+	}
+	q := make([]F, len(idx))
+	for i, j := range idx {
+		q[i] = pts[j]
+	}
+	segs := LineSegments(q).Segments()
+	if closed {
+		segs = Polygon(q).Segments()
+	}
+	crossing := make(map[int]bool)
+	for _, x := range Intersections(segs) {
+		for i, a := range x.Segments {
+			for _, b := range x.Segments[i+1:] {
+				adjacent := b-a == 1
+				if closed {
+					adjacent = adjacentSides(a, b, len(q))
+				}
+				if !adjacent {
+					crossing[a], crossing[b] = true, true
+				}
+			}
+		}
+	}
+	restored := false
+	for seg := range crossing {
+		end := len(pts)
+		if seg+1 < len(idx) {
+			end = idx[seg+1]
+		}
+		if restore(pts, keep, idx[seg], end) {
+			restored = true
+		}
+	}
+	return restored
+}
+
+// restore keeps the removed point between start and end, which may equal
+// len(pts) to refer to the first point, that is farthest from the segment
+// between them. It returns false if there are no removed points.
+func restore(pts []F, keep []bool, start, end int) bool {
+	a, b := pts[start], pts[end%len(pts)]
+	best, d := -1, -1.0
+	for i := start + 1; i < end; i++ {
+		if fd := segmentDistance(pts[i], a, b); !keep[i] && fd > d {
+			best, d = i, fd
+		}
+	}
+	if best == -1 {
+		return false
+	}
+	keep[best] = true
+	return true
+}
+
+// rdp marks the points between start and end that are kept by the
+// Ramer–Douglas–Peucker algorithm. A stack is used instead of recursion so
+// long inputs do not grow the call stack.
+func rdp(pts []F, keep []bool, start, end int, epsilon float64) {
+	stack := [][2]int{{start, end}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		a, b := pts[s[0]], pts[s[1]]
+		far, d := -1, epsilon
+		for i := s[0] + 1; i < s[1]; i++ {
+			if fd := segmentDistance(pts[i], a, b); fd > d {
+				far, d = i, fd
+			}
+		}
+		if far != -1 {
+			keep[far] = true
+			stack = append(stack, [2]int{s[0], far}, [2]int{far, s[1]})
+		}
+	}
+}
+
+// radial marks each point that is more than dist from the last point that was
+// kept, starting with the first point.
+func radial(pts []F, dist float64) []bool {
+	keep := make([]bool, len(pts))
+	keep[0] = true
+	last := pts[0]
+	for i, f := range pts[1:] {
+		if f.Distance(last) > dist {
+			keep[i+1] = true
+			last = f
+		}
+	}
+	return keep
+}
+
+// vwPoint is a point in the Visvalingam–Whyatt heap. The points that are still
+// kept form a linked list through prev and next.
+type vwPoint struct {
+	idx, prev, next int
+	area            float64
+	heapIdx         int
+}
+
+type vwHeap []*vwPoint
+
+func (h vwHeap) Len() int           { return len(h) }
+func (h vwHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h vwHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIdx, h[j].heapIdx = i, j
+}
+func (h *vwHeap) Push(x interface{}) {
+	pt := x.(*vwPoint)
+	pt.heapIdx = len(*h)
+	*h = append(*h, pt)
+}
+func (h *vwHeap) Pop() interface{} {
+	old := *h
+	pt := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return pt
+}
+
+// visvalingam marks the points that are kept by the Visvalingam–Whyatt
+// algorithm. If closed is true, the first and last points are neighbors and
+// at least 3 points are kept, otherwise the first and last points are kept.
+func visvalingam(pts []F, area float64, closed bool) []bool {
+	n := len(pts)
+	keep := make([]bool, n)
+	vs := make([]*vwPoint, n)
+	for i := range pts {
+		keep[i] = true
+		vs[i] = &vwPoint{idx: i, prev: (i + n - 1) % n, next: (i + 1) % n}
+	}
+	triangle := func(v *vwPoint) float64 {
+		return math.Abs(Orient(pts[v.prev], pts[v.idx], pts[v.next])) / 2
+	}
+
+	h := make(vwHeap, 0, n)
+	for i, v := range vs {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+		v.area = triangle(v)
+		heap.Push(&h, v)
+	}
+
+	remaining, least := n, 2
+	if closed {
+		least = 3
+	}
+	for h.Len() > 0 && remaining > least {
+		v := heap.Pop(&h).(*vwPoint)
+		if v.area >= area {
+			break
+		}
+		keep[v.idx] = false
+		remaining--
+		vs[v.prev].next, vs[v.next].prev = v.next, v.prev
+		// a neighbor's area is never less than the area of the point removed
+		// before it, so points are removed in order of their effective area
+		for _, nb := range []*vwPoint{vs[v.prev], vs[v.next]} {
+			if !closed && (nb.idx == 0 || nb.idx == n-1) {
+				continue
+			}
+			nb.area = math.Max(triangle(nb), v.area)
+			heap.Fix(&h, nb.heapIdx)
+		}
+	}
+	return keep
+}
+
+func countKept(keep []bool) int {
+	var c int
+	for _, k := range keep {
+		if k {
+			c++
+		}
+	}
+	return c
+}
+
+// kept returns the points that are kept.
+func kept(pts []F, keep []bool) []F {
+	out := make([]F, 0, countKept(keep))
+	for i, k := range keep {
+		if k {
+			out = append(out, pts[i])
+		}
+	}
+	return out
+}
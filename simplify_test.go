@@ -0,0 +1,146 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLineSegmentsSimplify(t *testing.T) {
+	ls := LineSegments{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 0}, {3, 3}}
+	expected := LineSegments{{0, 0}, {3, 0}, {3, 3}}
+	assert.Equal(t, expected, ls.SimplifyRDP(0.5, false))
+	assert.Equal(t, expected, ls.SimplifyVW(0.5, false))
+	assert.Equal(t, ls, ls.SimplifyRDP(0.01, false))
+	assert.Equal(t, ls, ls.SimplifyVW(0.01, false))
+	assert.Equal(t, LineSegments{{0, 0}, {3, 3}}, ls.SimplifyRDP(5, false))
+	assert.Equal(t, LineSegments{{0, 0}, {3, 3}}, ls.SimplifyVW(5, false))
+
+	ls = LineSegments{{0, 0}, {0.1, 0}, {0.2, 0}, {1, 0}, {1.05, 0}, {2, 0}, {2.1, 0}}
+	assert.Equal(t, LineSegments{{0, 0}, {1, 0}, {2, 0}, {2.1, 0}}, ls.SimplifyRadial(0.5, false))
+
+	short := LineSegments{{0, 0}, {1, 1}}
+	assert.Equal(t, short, short.SimplifyRDP(10, false))
+	assert.Equal(t, short, short.SimplifyVW(10, false))
+	assert.Equal(t, short, short.SimplifyRadial(10, false))
+}
+
+func TestLineSegmentsSimplifyRDPDeviation(t *testing.T) {
+	r := rand.New(rand.NewSource(271))
+	ls := make(LineSegments, 1000)
+	for i := range ls {
+		x := float64(i) / 100
+		ls[i] = F{x, math.Sin(x) + r.Float64()*0.01}
+	}
+	const eps = 0.05
+	s := ls.SimplifyRDP(eps, false)
+	assert.Less(t, len(s), 50)
+	assert.Equal(t, ls[0], s[0])
+	assert.Equal(t, ls[len(ls)-1], s[len(s)-1])
+	for _, f := range ls {
+		_, _, idx := s.Closest(f)
+		assert.LessOrEqual(t, segmentDistance(f, s[idx], s[idx+1]), eps)
+	}
+}
+
+// lineCrosses returns true if two segments of ls that do not follow each other
+// intersect.
+func lineCrosses(ls LineSegments) bool {
+	for _, x := range Intersections(ls.Segments()) {
+		for i, a := range x.Segments {
+			for _, b := range x.Segments[i+1:] {
+				if b-a != 1 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func TestLineSegmentsSimplifyKeepSimple(t *testing.T) {
+	// a hook that turns back under its start, removing (2,2) makes the last
+	// segment cross the first
+	ls := LineSegments{{3, 3}, {2, 8}, {0, 8}, {0, 2}, {2, 2}, {5, 4}}
+	assert.False(t, lineCrosses(ls))
+	assert.True(t, lineCrosses(ls.SimplifyRDP(1.5, false)))
+	assert.True(t, lineCrosses(ls.SimplifyVW(3, false)))
+	assert.Equal(t, ls, ls.SimplifyRDP(1.5, true))
+	assert.Equal(t, ls, ls.SimplifyVW(3, true))
+
+	// random lines that do not cross themselves
+	r := rand.New(rand.NewSource(161))
+	var plain int
+	for n := 0; n < 1000; n++ {
+		ls := make(LineSegments, 6)
+		for i := range ls {
+			ls[i] = F{r.Float64() * 10, r.Float64() * 10}
+		}
+		if lineCrosses(ls) {
+			continue
+		}
+		for _, tol := range []float64{1, 2, 4} {
+			if lineCrosses(ls.SimplifyRDP(tol, false)) || lineCrosses(ls.SimplifyVW(tol, false)) {
+				plain++
+			}
+			assert.False(t, lineCrosses(ls.SimplifyRDP(tol, true)), ls)
+			assert.False(t, lineCrosses(ls.SimplifyVW(tol, true)), ls)
+			assert.False(t, lineCrosses(ls.SimplifyRadial(tol, true)), ls)
+		}
+	}
+	assert.Greater(t, plain, 0)
+}
+
+func TestPolygonSimplify(t *testing.T) {
+	// a circle with a little noise
+	r := rand.New(rand.NewSource(314))
+	p := make(Polygon, 200)
+	for i := range p {
+		a := 2 * math.Pi * float64(i) / float64(len(p))
+		rad := 10 + r.Float64()*0.1
+		p[i] = F{rad * math.Cos(a), rad * math.Sin(a)}
+	}
+	for _, s := range []Polygon{
+		p.SimplifyRDP(0.5, false),
+		p.SimplifyVW(1, false),
+		p.SimplifyRadial(2, false),
+	} {
+		assert.Less(t, len(s), 40)
+		assert.Greater(t, len(s), 6)
+		assert.InDelta(t, p.Area(), s.Area(), 0.1*p.Area())
+	}
+
+	assert.Equal(t, p[0], p.SimplifyRDP(0.5, false)[0])
+	assert.Equal(t, p[0], p.SimplifyRadial(2, false)[0])
+
+	// a polygon is never reduced below a triangle
+	for _, s := range []Polygon{
+		p.SimplifyRDP(100, false),
+		p.SimplifyVW(1000, false),
+		p.SimplifyRadial(100, false),
+	} {
+		assert.Len(t, s, 3)
+		assert.Greater(t, s.Area(), 10.0)
+	}
+
+	tri := Polygon{{0, 0}, {1, 0}, {0, 1}}
+	assert.Equal(t, tri, tri.SimplifyRDP(10, false))
+}
+
+func TestPolygonSimplifyKeepSimple(t *testing.T) {
+	// a notch reaches up from the bottom to just under the bump on the top
+	p := Polygon{{0, 0}, {5, 1}, {10, 0}, {10, -3}, {5.5, -3}, {5, 0.5}, {4.5, -3}, {0, -3}}
+	assert.True(t, p.NonIntersecting())
+
+	s := p.SimplifyRDP(1.5, false)
+	assert.Equal(t, Polygon{{0, 0}, {10, 0}, {10, -3}, {5.5, -3}, {5, 0.5}, {4.5, -3}, {0, -3}}, s)
+	assert.False(t, s.NonIntersecting())
+	assert.Equal(t, p, p.SimplifyRDP(1.5, true))
+
+	for _, tol := range []float64{0.5, 1, 2, 4, 8} {
+		assert.True(t, p.SimplifyRDP(tol, true).NonIntersecting())
+		assert.True(t, p.SimplifyVW(tol, true).NonIntersecting())
+		assert.True(t, p.SimplifyRadial(tol, true).NonIntersecting())
+	}
+}
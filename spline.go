@@ -0,0 +1,192 @@
+package vec2d
+
+import (
+	"math"
+)
+
+// Hermite is a cubic Hermite spline that passes through each of the Points.
+// The segment from point i to point i+1 leaves point i with the tangent Out[i]
+// and arrives at point i+1 with the tangent In[i+1]. The tangents are relative
+// to the segment, where t goes from 0 to 1 across each segment. Like
+// LineSegments, t is spread evenly across the segments, so t=0 is the first
+// point and t=1 is the last. Hermite fulfills Path.
+type Hermite struct {
+	Points  []F
+	In, Out []F
+}
+
+// NewHermite returns the Hermite spline through points with the tangent at
+// each point used for both the segment arriving at and leaving the point.
+func NewHermite(points, tangents []F) Hermite {
+	return Hermite{
+		Points: points,
+		In:     tangents,
+		Out:    tangents,
+	}
+}
+
+// NewKochanekBartels returns a spline through the points with tangents set by
+// tension, bias and continuity. Each is 0 for a Catmull-Rom spline. Tension
+// controls the length of the tangents, 1 makes the curve straight between the
+// points and -1 makes it more rounded. Bias moves the curve towards the
+// previous point when positive and the next point when negative. Continuity
+// other than 0 gives the tangent arriving at a point a different direction from
+// the tangent leaving it, which makes a corner.
+//
+// The first and last points use a point reflected through them in place of the
+// missing neighbor.
+func NewKochanekBartels(tension, bias, continuity float64, points ...F) Hermite {
+	n := len(points)
+	h := Hermite{
+		Points: points,
+		In:     make([]F, n),
+		Out:    make([]F, n),
+	}
+	if n < 2 {
+		return h
+	}
+	a := (1 - tension) * (1 + bias) / 2
+	b := (1 - tension) * (1 - bias) / 2
+	for i, p := range points {
+		prev, next := neighbors(points, i)
+		d0, d1 := p.Subtract(prev), next.Subtract(p)
+		h.In[i] = d0.ScalarMultiply(a * (1 - continuity)).Add(d1.ScalarMultiply(b * (1 + continuity)))
+		h.Out[i] = d0.ScalarMultiply(a * (1 + continuity)).Add(d1.ScalarMultiply(b * (1 - continuity)))
+	}
+	return h
+}
+
+// NewCardinal returns a cardinal spline through the points. A tension of 0
+// gives a Catmull-Rom spline and a tension of 1 gives straight lines between
+// the points. See NewKochanekBartels.
+func NewCardinal(tension float64, points ...F) Hermite {
+	return NewKochanekBartels(tension, 0, 0, points...)
+}
+
+// NewCatmullRom returns a uniform Catmull-Rom spline through the points. The
+// tangent at each point is half of the difference between its neighbors.
+func NewCatmullRom(points ...F) Hermite {
+	return NewCardinal(0, points...)
+}
+
+// NewCentripetalCatmullRom returns a Catmull-Rom spline through the points
+// with an alpha of 0.5. Unlike the uniform spline, it does not form loops or
+// cusps within a segment when the points are unevenly spaced. See
+// NewCatmullRomAlpha.
+func NewCentripetalCatmullRom(points ...F) Hermite {
+	return NewCatmullRomAlpha(0.5, points...)
+}
+
+// NewCatmullRomAlpha returns a Catmull-Rom spline through the points where
+// the spacing of the knots is the distance between the points raised to alpha.
+// An alpha of 0 gives the uniform spline, 0.5 the centripetal spline and 1 the
+// chordal spline. The spline is still evaluated with t spread evenly across
+// the segments.
+func NewCatmullRomAlpha(alpha float64, points ...F) Hermite {
+	n := len(points)
+	h := Hermite{
+		Points: points,
+		In:     make([]F, n),
+		Out:    make([]F, n),
+	}
+	if n < 2 {
+		return h
+	}
+	knot := func(a, b F) float64 {
+		if d := math.Pow(a.Distance(b), alpha); d > 0 {
+			return d
+		}
+		return 1
+	}
+	for i, p := range points {
+		prev, next := neighbors(points, i)
+		dt0, dt1 := knot(prev, p), knot(p, next)
+		// the velocity at p with respect to the knots
+		v := p.Subtract(prev).ScalarMultiply(1 / dt0).
+			Subtract(next.Subtract(prev).ScalarMultiply(1 / (dt0 + dt1))).
+			Add(next.Subtract(p).ScalarMultiply(1 / dt1))
+		h.In[i] = v.ScalarMultiply(dt0)
+		h.Out[i] = v.ScalarMultiply(dt1)
+	}
+	return h
+}
+
+// neighbors returns the points before and after the point at i. The first and
+// last points are reflected through the point at i when there is no neighbor.
+func neighbors(points []F, i int) (prev, next F) {
+	p := points[i]
+	if i > 0 {
+		prev = points[i-1]
+	} else {
+		prev = p.ScalarMultiply(2).Subtract(points[i+1])
+	}
+	if i < len(points)-1 {
+		next = points[i+1]
+	} else {
+		next = p.ScalarMultiply(2).Subtract(points[i-1])
+	}
+	return
+}
+
+// segment returns the index of the segment that t falls on and the value of t
+// within that segment, the same as LineSegments.
+func (h Hermite) segment(t float64) (int, float64) {
+	return LineSegments(h.Points).segment(t)
+}
+
+// F returns the point on the spline at t. Fulfills Path.
+func (h Hermite) F(t float64) F {
+	switch len(h.Points) {
+	case 0:
+		return F{}
+	case 1:
+		return h.Points[0]
+	}
+	i, u := h.segment(t)
+	if u == 0 {
+		return h.Points[i]
+	}
+	if u == 1 {
+		return h.Points[i+1]
+	}
+	u2 := u * u
+	u3 := u2 * u
+	return h.Points[i].ScalarMultiply(2*u3 - 3*u2 + 1).
+		Add(h.Out[i].ScalarMultiply(u3 - 2*u2 + u)).
+		Add(h.Points[i+1].ScalarMultiply(-2*u3 + 3*u2)).
+		Add(h.In[i+1].ScalarMultiply(u3 - u2))
+}
+
+// Tangent returns the derivative of F at t. At a point where In and Out differ,
+// the tangent of the following segment is returned. Fulfills Path.
+func (h Hermite) Tangent(t float64) F {
+	if len(h.Points) < 2 {
+		return F{}
+	}
+	i, u := h.segment(t)
+	u2 := u * u
+	d := h.Points[i].ScalarMultiply(6*u2 - 6*u).
+		Add(h.Out[i].ScalarMultiply(3*u2 - 4*u + 1)).
+		Add(h.Points[i+1].ScalarMultiply(-6*u2 + 6*u)).
+		Add(h.In[i+1].ScalarMultiply(3*u2 - 2*u))
+	return d.ScalarMultiply(float64(len(h.Points) - 1))
+}
+
+// CompositeBezier converts the spline to a CompositeBezier with a cubic
+// segment between each pair of points. Both produce the same point at every t.
+func (h Hermite) CompositeBezier() CompositeBezier {
+	if len(h.Points) < 2 {
+		return newCompositeBezier(nil)
+	}
+	points := make([][]F, len(h.Points)-1)
+	for i := range points {
+		p0, p1 := h.Points[i], h.Points[i+1]
+		points[i] = []F{
+			p0,
+			p0.Add(h.Out[i].ScalarMultiply(1.0 / 3)),
+			p1.Subtract(h.In[i+1].ScalarMultiply(1.0 / 3)),
+			p1,
+		}
+	}
+	return newCompositeBezier(points)
+}
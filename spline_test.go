@@ -0,0 +1,102 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHermite(t *testing.T) {
+	h := NewHermite([]F{{0, 0}, {1, 0}}, []F{{0, 1}, {0, -1}})
+	assert.Equal(t, F{0, 0}, h.F(0))
+	assert.Equal(t, F{0.5, 0.25}, h.F(0.5))
+	assert.Equal(t, F{1, 0}, h.F(1))
+	assert.Equal(t, F{0, 1}, h.Tangent(0))
+	assert.Equal(t, F{1.5, 0}, h.Tangent(0.5))
+	assert.Equal(t, F{0, -1}, h.Tangent(1))
+
+	var p Path = h
+	assert.NotNil(t, p)
+	assert.Equal(t, F{2, 3}, NewHermite([]F{{2, 3}}, []F{{1, 1}}).F(0.5))
+	assert.Equal(t, F{}, Hermite{}.F(0.5))
+}
+
+func splines(pts ...F) map[string]Hermite {
+	return map[string]Hermite{
+		"catmull-rom": NewCatmullRom(pts...),
+		"centripetal": NewCentripetalCatmullRom(pts...),
+		"chordal":     NewCatmullRomAlpha(1, pts...),
+		"cardinal":    NewCardinal(0.5, pts...),
+		"kb":          NewKochanekBartels(0.2, -0.3, 0.4, pts...),
+	}
+}
+
+func TestSplinesInterpolate(t *testing.T) {
+	pts := []F{{0, 0}, {1, 3}, {2, 3.2}, {6, 0}, {7, 5}}
+	for name, h := range splines(pts...) {
+		for i, f := range pts {
+			assert.Equal(t, f, h.F(float64(i)/4), name)
+		}
+
+		cb := h.CompositeBezier()
+		const d = 1e-6
+		for i := 0; i <= 40; i++ {
+			ti := float64(i) / 40
+			assert.True(t, h.F(ti).Near(cb.F(ti), 1e-9), name)
+			if i%10 == 0 {
+				// In and Out may differ at the points
+				continue
+			}
+			// the tangent is the derivative of F
+			df := h.F(ti + d).Subtract(h.F(ti - d)).ScalarMultiply(1 / (2 * d))
+			assert.True(t, df.Near(h.Tangent(ti), 1e-4), name)
+		}
+		assert.Len(t, cb.Points(), 4)
+	}
+}
+
+func TestCatmullRom(t *testing.T) {
+	pts := []F{{0, 0}, {1, 1}, {3, 1}, {4, 0}}
+	h := NewCatmullRom(pts...)
+	assert.Equal(t, F{1.5, 0.5}, h.Out[1])
+	assert.Equal(t, h.Out, h.In)
+	// the ends use a reflected neighbor
+	assert.Equal(t, F{1, 1}, h.Out[0])
+	assert.Equal(t, F{4.5, 1.5}, h.Tangent(1.0/3))
+
+	// alpha 0 is the uniform spline
+	u := NewCatmullRomAlpha(0, pts...)
+	for i := range pts {
+		assert.True(t, u.In[i].Near(h.In[i], 1e-12))
+		assert.True(t, u.Out[i].Near(h.Out[i], 1e-12))
+	}
+
+	// points that are close together make the uniform spline loop, the
+	// centripetal spline stays between them
+	pts = []F{{0, 0}, {10, 0}, {10.5, 0.5}, {10, 1}, {0, 1}}
+	c := NewCentripetalCatmullRom(pts...)
+	h = NewCatmullRom(pts...)
+	var maxC, maxU float64
+	for i := 0; i <= 100; i++ {
+		ti := float64(i) / 100
+		maxC = max(maxC, c.F(ti).X)
+		maxU = max(maxU, h.F(ti).X)
+	}
+	assert.Less(t, maxC, 10.7)
+	assert.Greater(t, maxU, maxC)
+}
+
+func TestCardinal(t *testing.T) {
+	pts := []F{{0, 0}, {2, 4}, {4, 0}}
+	assert.Equal(t, NewCatmullRom(pts...), NewCardinal(0, pts...))
+	assert.Equal(t, NewCatmullRom(pts...), NewKochanekBartels(0, 0, 0, pts...))
+
+	// a tension of 1 gives straight lines
+	h := NewCardinal(1, pts...)
+	assert.Equal(t, F{1, 2}, h.F(0.25))
+	assert.Equal(t, F{3, 2}, h.F(0.75))
+
+	// continuity makes a corner
+	kb := NewKochanekBartels(0, 0, -1, pts...)
+	assert.Equal(t, F{2, 4}, kb.In[1])
+	assert.Equal(t, F{2, -4}, kb.Out[1])
+}
@@ -0,0 +1,271 @@
+package vec2d
+
+import (
+	"math"
+)
+
+// Join is the shape used where two segments of a Stroke meet.
+type Join byte
+
+const (
+	// JoinMiter extends the outer edges of both segments until they meet. If
+	// the corner would be too long, JoinBevel is used instead. See
+	// Stroke.MiterLimit.
+	JoinMiter Join = iota
+	// JoinRound connects the outer edges with an arc centered on the point
+	// where the segments meet.
+	JoinRound
+	// JoinBevel connects the outer edges with a straight line.
+	JoinBevel
+)
+
+// String returns the name of the Join.
+func (j Join) String() string {
+	switch j {
+	case JoinRound:
+		return "Round"
+	case JoinBevel:
+		return "Bevel"
+	}
+	return "Miter"
+}
+
+// Cap is the shape used at the ends of a Stroke.
+type Cap byte
+
+const (
+	// CapButt ends the stroke at the end point.
+	CapButt Cap = iota
+	// CapRound ends the stroke with a half circle centered on the end point.
+	CapRound
+	// CapSquare extends the stroke past the end point by half the width.
+	CapSquare
+)
+
+// String returns the name of the Cap.
+func (c Cap) String() string {
+	switch c {
+	case CapRound:
+		return "Round"
+	case CapSquare:
+		return "Square"
+	}
+	return "Butt"
+}
+
+const (
+	// DefaultMiterLimit is used by a Stroke that does not set MiterLimit.
+	DefaultMiterLimit = 4.0
+	// DefaultRoundAngle is used by a Stroke that does not set RoundAngle.
+	DefaultRoundAngle = math.Pi / 16
+)
+
+// Stroke describes how to outline a line with a Width. The outline of a
+// stroke is a Polygon that can be used with Contains, Fill or FindTriangles.
+// Where segments are shorter than the Width at a sharp turn, the inside of the
+// turn may overlap itself.
+type Stroke struct {
+	Width float64
+	Join  Join
+	Cap   Cap
+	// MiterLimit is the greatest ratio of the length of a miter, from the
+	// point where the segments meet to the tip of the corner, to half the
+	// Width. Sharper corners use JoinBevel. This is the same as the
+	// stroke-miterlimit in SVG. If it is 0, DefaultMiterLimit is used.
+	MiterLimit float64
+	// RoundAngle is the greatest angle, in radians, between the points of a
+	// round join or cap. If it is 0, DefaultRoundAngle is used.
+	RoundAngle float64
+	// Flattener is used by OutlineCurve. If it is the zero value, a Flattener
+	// with a Deviation of a twentieth of the Width and an Angle of RoundAngle
+	// is used.
+	Flattener Flattener
+}
+
+// Outline returns the outline of ls drawn with the stroke. The outline
+// proceeds counter clockwise, starting on the right side of the first
+// segment. Repeated points are ignored. A single point is drawn as a circle
+// with CapRound, a square with CapSquare and nil with CapButt.
+func (s Stroke) Outline(ls LineSegments) Polygon {
+	pts := dedupe(ls, false)
+	hw := s.Width / 2
+	if len(pts) == 0 || hw <= 0 {
+		return nil
+	}
+	if len(pts) == 1 {
+		p := pts[0]
+		switch s.Cap {
+		case CapRound:
+			out := Polygon{p.Add(F{hw, 0})}
+			return s.arc(out, p, F{hw, 0}, 2*math.Pi)
+		case CapSquare:
+			return RectangleToPoints(p.Subtract(F{hw, hw}), p.Add(F{hw, hw}))
+		}
+		return nil
+	}
+
+	rev := make([]F, len(pts))
+	for i, f := range pts {
+		rev[len(pts)-1-i] = f
+	}
+	out := s.side(nil, pts, false)
+	out = s.cap(out, pts)
+	out = s.side(out, rev, false)
+	out = s.cap(out, rev)
+	return out
+}
+
+// OutlineClosed returns the outlines of the stroke drawn along the sides of p.
+// The outer outline proceeds counter clockwise and the inner outline proceeds
+// clockwise, regardless of the direction of p. Caps are not used.
+func (s Stroke) OutlineClosed(p Polygon) (outer, inner Polygon) {
+	pts := dedupe(p, true)
+	if len(pts) < 2 || s.Width <= 0 {
+		return nil, nil
+	}
+	rev := make([]F, len(pts))
+	for i, f := range pts {
+		rev[len(pts)-1-i] = f
+	}
+	outer = s.side(nil, pts, true)
+	inner = s.side(nil, rev, true)
+	if Polygon(pts).SignedArea() < 0 {
+		outer, inner = inner, outer
+	}
+	return
+}
+
+// OutlineCurve flattens c and returns the outline of the line segments. See
+// Outline and Flattener.
+func (s Stroke) OutlineCurve(c Curver) Polygon {
+	f := s.Flattener
+	if f == (Flattener{}) {
+		f = Flattener{
+			Deviation: s.Width / 20,
+			Angle:     s.roundAngle(),
+		}
+	}
+	ls, _ := f.Flatten(c)
+	return s.Outline(ls)
+}
+
+func (s Stroke) roundAngle() float64 {
+	if s.RoundAngle > 0 {
+		return s.RoundAngle
+	}
+	return DefaultRoundAngle
+}
+
+func (s Stroke) miterLimit() float64 {
+	if s.MiterLimit > 0 {
+		return s.MiterLimit
+	}
+	return DefaultMiterLimit
+}
+
+// dedupe removes points that are the same as the point before them. If closed
+// is true, the last point is also removed if it is the same as the first.
+func dedupe(pts []F, closed bool) []F {
+	out := make([]F, 0, len(pts))
+	for i, f := range pts {
+		if i == 0 || f != pts[i-1] {
+			out = append(out, f)
+		}
+	}
+	if closed && len(out) > 1 && out[len(out)-1] == out[0] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// side appends the right side of the stroke along pts to out. If closed is
+// true, pts is treated as a polygon and there is a join at every point.
+func (s Stroke) side(out, pts []F, closed bool) []F {
+	hw := s.Width / 2
+	n := len(pts)
+	// normal returns the offset to the right side of the segment from point
+	// i to point i+1
+	normal := func(i int) F {
+		d := pts[(i+1)%n].Subtract(pts[i]).Normalize()
+		return F{d.Y, -d.X}.ScalarMultiply(hw)
+	}
+	if !closed {
+		out = append(out, pts[0].Add(normal(0)))
+		for i := 1; i < n-1; i++ {
+			out = s.join(out, pts[i-1], pts[i], pts[i+1], normal(i-1), normal(i))
+		}
+		return append(out, pts[n-1].Add(normal(n-2)))
+	}
+	for i := range pts {
+		prev := (i + n - 1) % n
+		out = s.join(out, pts[prev], pts[i], pts[(i+1)%n], normal(prev), normal(i))
+	}
+	return out
+}
+
+// join appends the right side of the join at p between the segment from
+// prev to p and the segment from p to next. The offsets to the right side of
+// each segment are a and b.
+func (s Stroke) join(out []F, prev, p, next, a, b F) []F {
+	turn := a.Cross(b)
+	if turn == 0 && a.Dot(b) > 0 {
+		// straight
+		return append(out, p.Add(a))
+	}
+	if turn < 0 {
+		// the right side is on the inside of the turn, so the edges are
+		// trimmed where they cross
+		x := Segment{prev.Add(a), p.Add(a)}.Intersect(Segment{p.Add(b), next.Add(b)})
+		if x.Kind == IntersectPoint {
+			return append(out, x.Point)
+		}
+		return append(out, p.Add(a), p.Add(b))
+	}
+
+	switch s.Join {
+	case JoinMiter:
+		hw2 := s.Width * s.Width / 4
+		if d := hw2 + a.Dot(b); d > 0 {
+			m := a.Add(b).ScalarMultiply(hw2 / d)
+			if m.Mag2() <= s.miterLimit()*s.miterLimit()*hw2 {
+				return append(out, p.Add(m))
+			}
+		}
+	case JoinRound:
+		angle := a.AngleTo(b)
+		if turn == 0 {
+			// turning back, the outside is in front of p
+			angle = math.Pi
+		}
+		out = append(out, p.Add(a))
+		out = s.arc(out, p, a, angle)
+		return append(out, p.Add(b))
+	}
+	return append(out, p.Add(a), p.Add(b))
+}
+
+// cap appends the cap at the last point of pts, from the right side of the
+// stroke to the left side, to out.
+func (s Stroke) cap(out, pts []F) []F {
+	n := len(pts)
+	p := pts[n-1]
+	d := p.Subtract(pts[n-2]).Normalize().ScalarMultiply(s.Width / 2)
+	r := F{d.Y, -d.X}
+	switch s.Cap {
+	case CapRound:
+		return s.arc(out, p, r, math.Pi)
+	case CapSquare:
+		return append(out, p.Add(r).Add(d), p.Subtract(r).Add(d))
+	}
+	return out
+}
+
+// arc appends the points between the ends of an arc around center that starts
+// at center+from and turns by angle, in radians.
+func (s Stroke) arc(out []F, center, from F, angle float64) []F {
+	steps := int(math.Ceil(math.Abs(angle) / s.roundAngle()))
+	for i := 1; i < steps; i++ {
+		out = append(out, center.Add(from.Rotate(angle*float64(i)/float64(steps))))
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestStrokeCaps(t *testing.T) {
+	ls := LineSegments{{0, 0}, {10, 0}}
+	s := Stroke{Width: 2}
+	assert.Equal(t, Polygon{{0, -1}, {10, -1}, {10, 1}, {0, 1}}, s.Outline(ls))
+
+	s.Cap = CapSquare
+	assert.Equal(t, Polygon{{0, -1}, {10, -1}, {11, -1}, {11, 1}, {10, 1}, {0, 1}, {-1, 1}, {-1, -1}}, s.Outline(ls))
+
+	s.Cap = CapRound
+	p := s.Outline(ls)
+	assert.InDelta(t, 20+math.Pi, p.Area(), 0.05)
+	assert.True(t, p.SignedArea() > 0)
+	for _, f := range p {
+		_, _, idx := ls.Closest(f)
+		assert.InDelta(t, 1, segmentDistance(f, ls[idx], ls[idx+1]), 1e-9)
+	}
+	assert.True(t, p.Contains(F{10.9, 0}))
+	assert.True(t, p.Contains(F{-0.9, 0}))
+
+	// a single point
+	pt := LineSegments{{1, 1}, {1, 1}}
+	assert.Nil(t, Stroke{Width: 2}.Outline(pt))
+	assert.InDelta(t, 4, Stroke{Width: 2, Cap: CapSquare}.Outline(pt).Area(), 1e-9)
+	assert.InDelta(t, math.Pi, Stroke{Width: 2, Cap: CapRound}.Outline(pt).Area(), 0.05)
+
+	assert.Nil(t, Stroke{}.Outline(ls))
+	assert.Nil(t, Stroke{Width: 1}.Outline(nil))
+}
+
+func TestStrokeJoins(t *testing.T) {
+	ls := LineSegments{{0, 0}, {10, 0}, {10, 10}}
+	s := Stroke{Width: 2}
+	assert.Equal(t, Polygon{{0, -1}, {11, -1}, {11, 10}, {9, 10}, {9, 1}, {0, 1}}, s.Outline(ls))
+
+	// the miter of a right angle is √2 times half the width
+	s.MiterLimit = 1.4
+	bevel := Polygon{{0, -1}, {10, -1}, {11, 0}, {11, 10}, {9, 10}, {9, 1}, {0, 1}}
+	assert.Equal(t, bevel, s.Outline(ls))
+	s.MiterLimit = 0
+	s.Join = JoinBevel
+	assert.Equal(t, bevel, s.Outline(ls))
+
+	s.Join = JoinRound
+	p := s.Outline(ls)
+	assert.InDelta(t, 40-1+math.Pi/4, p.Area(), 0.01)
+	for _, f := range p {
+		_, _, idx := ls.Closest(f)
+		assert.InDelta(t, 1, segmentDistance(f, ls[idx], ls[idx+1]), 1e-9)
+	}
+
+	// turning back on itself
+	back := LineSegments{{0, 0}, {10, 0}, {0, 0.5}}
+	p = Stroke{Width: 2, Join: JoinRound}.Outline(back)
+	assert.True(t, p.Contains(F{10.9, 0}))
+	p = Stroke{Width: 2}.Outline(back)
+	assert.False(t, p.Contains(F{10.9, 0}))
+
+	// straight joins add a single point on each side
+	assert.Equal(t, Polygon{{0, -1}, {5, -1}, {10, -1}, {10, 1}, {5, 1}, {0, 1}},
+		Stroke{Width: 2}.Outline(LineSegments{{0, 0}, {5, 0}, {5, 0}, {10, 0}}))
+}
+
+func TestStrokeClosed(t *testing.T) {
+	sq := RectangleToPoints(F{0, 0}, F{10, 10})
+	s := Stroke{Width: 2}
+	outer, inner := s.OutlineClosed(sq)
+	assert.InDelta(t, 144, outer.SignedArea(), 1e-9)
+	assert.InDelta(t, -64, inner.SignedArea(), 1e-9)
+
+	// the same regardless of direction
+	rev := Polygon{sq[3], sq[2], sq[1], sq[0]}
+	o2, i2 := s.OutlineClosed(rev)
+	assert.InDelta(t, 144, o2.SignedArea(), 1e-9)
+	assert.InDelta(t, -64, i2.SignedArea(), 1e-9)
+
+	s.Join = JoinRound
+	outer, _ = s.OutlineClosed(sq)
+	assert.InDelta(t, 100+40+math.Pi, outer.Area(), 0.05)
+}
+
+func TestStrokeCurve(t *testing.T) {
+	arc := Curve(func(t float64) F {
+		a := t * math.Pi
+		return F{10 * math.Cos(a), 10 * math.Sin(a)}
+	})
+	s := Stroke{Width: 2}
+	p := s.OutlineCurve(arc)
+	// half of a ring from radius 9 to 11
+	assert.InDelta(t, math.Pi*(121-81)/2, p.Area(), 0.2)
+	assert.True(t, p.Contains(F{0, 10.9}))
+	assert.False(t, p.Contains(F{0, 8.9}))
+
+	bp := NewBezierPath(F{0, 0}, F{5, 10}, F{10, 0})
+	p = s.OutlineCurve(bp)
+	ls, _ := FlattenDeviation(bp, 0.01)
+	assert.InDelta(t, 2*ls.Length(), p.Area(), 0.2)
+}
+
+func TestJoinCapString(t *testing.T) {
+	assert.Equal(t, "Miter", JoinMiter.String())
+	assert.Equal(t, "Round", JoinRound.String())
+	assert.Equal(t, "Bevel", JoinBevel.String())
+	assert.Equal(t, "Butt", CapButt.String())
+	assert.Equal(t, "Round", CapRound.String())
+	assert.Equal(t, "Square", CapSquare.String())
+}
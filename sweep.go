@@ -0,0 +1,467 @@
+package vec2d
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// SegmentIntersection is a point where two or more segments meet, found by
+// Intersections.
+type SegmentIntersection struct {
+	Point F
+	// Segments holds the index of each segment that contains Point, in
+	// increasing order.
+	Segments []int
+}
+
+// Intersections finds every point where two or more of the segments meet,
+// including segments that only touch at their ends. The results are ordered
+// by Y and then by X. Segments that are collinear and overlap are reported at
+// the points where the overlap starts and ends. A segment with both ends at
+// the same point is treated as a point.
+//
+// This uses a Bentley–Ottmann sweep, which takes O((n+k) log n) expected time
+// for n segments and k intersections, where k counts each segment at each
+// point it meets another. The segments crossing the sweep line are held in a
+// balanced tree so each event only touches the segments at the event and their
+// neighbors. Orientation tests are exact, but
+// intersection points between segments that cross are computed with float64,
+// so when three or more segments cross at a point that cannot be represented
+// exactly, the point may be reported more than once with different pairs of
+// segments.
+func Intersections(segs []Segment) []SegmentIntersection {
+	sw := &sweep{
+		segs:   make([]Segment, len(segs)),
+		events: make(map[F]*sweepEvent),
+		nodes:  make(map[int]*statusNode),
+		seed:   0x9E3779B97F4A7C15,
+	}
+	for i, s := range segs {
+		if before(s[1], s[0]) {
+			s[0], s[1] = s[1], s[0]
+		}
+		sw.segs[i] = s
+		if s[0] == s[1] {
+			e := sw.event(s[0])
+			e.points = append(e.points, i)
+			continue
+		}
+		e := sw.event(s[0])
+		e.upper = append(e.upper, i)
+		sw.event(s[1])
+	}
+	for sw.queue.Len() > 0 {
+		e := heap.Pop(&sw.queue).(*sweepEvent)
+		delete(sw.events, e.pt)
+		sw.handle(e)
+	}
+	return sw.out
+}
+
+// Segments returns the sides of the polygon. The segment at index i is the
+// side from vertex i to vertex i+1, with the last side returning to the first
+// vertex.
+func (p Polygon) Segments() []Segment {
+	out := make([]Segment, len(p))
+	for i, f := range p {
+		out[i] = Segment{f, p[(i+1)%len(p)]}
+	}
+	return out
+}
+
+// Segments returns each segment of ls. The segment at index i is from point i
+// to point i+1.
+func (ls LineSegments) Segments() []Segment {
+	if len(ls) < 2 {
+		return nil
+	}
+	out := make([]Segment, len(ls)-1)
+	for i := range out {
+		out[i] = Segment{ls[i], ls[i+1]}
+	}
+	return out
+}
+
+// before defines the order of the sweep, by Y and then by X.
+func before(a, b F) bool {
+	return a.Y < b.Y || (a.Y == b.Y && a.X < b.X)
+}
+
+type sweepEvent struct {
+	pt F
+	// upper holds the segments that start at pt
+	upper []int
+	// points holds the zero length segments at pt
+	points []int
+	// crossing holds the segments that were found to cross at pt
+	crossing []int
+}
+
+type eventQueue []*sweepEvent
+
+func (q eventQueue) Len() int            { return len(q) }
+func (q eventQueue) Less(i, j int) bool  { return before(q[i].pt, q[j].pt) }
+func (q eventQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*sweepEvent)) }
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+type sweep struct {
+	// segs are oriented so the first point is before the second
+	segs   []Segment
+	queue  eventQueue
+	events map[F]*sweepEvent
+	// status holds the segments crossing the sweep line ordered by X
+	status *statusNode
+	// nodes holds the node in status for each segment
+	nodes map[int]*statusNode
+	// seed is used to choose the priority of each node
+	seed uint64
+	out  []SegmentIntersection
+}
+
+func (sw *sweep) event(pt F) *sweepEvent {
+	e, ok := sw.events[pt]
+	if !ok {
+		e = &sweepEvent{pt: pt}
+		sw.events[pt] = e
+		heap.Push(&sw.queue, e)
+	}
+	return e
+}
+
+// side returns a positive value if pt is left of segment i, negative if it is
+// right and 0 if it is on the segment.
+func (sw *sweep) side(i int, pt F) int {
+	s := sw.segs[i]
+	return sign(Orient(s[0], s[1], pt))
+}
+
+// collinear returns true if segments a and b lie on the same line.
+func (sw *sweep) collinear(a, b int) bool {
+	sa, sb := sw.segs[a], sw.segs[b]
+	return crossDiff(sa[0], sa[1], sb[0], sb[1]) == 0 && Orient(sa[0], sa[1], sb[0]) == 0
+}
+
+// at returns the segment at idx in the status.
+func (sw *sweep) at(idx int) int {
+	return sw.status.at(idx).seg
+}
+
+func (sw *sweep) handle(e *sweepEvent) {
+	p := e.pt
+	ln := sw.status.len()
+
+	// find the segments in the status that contain p, they are contiguous
+	l := sw.status.search(func(i int) bool {
+		return sw.side(i, p) >= 0
+	})
+	r := l
+	for r < ln && sw.side(sw.at(r), p) == 0 {
+		r++
+	}
+	// p may not be exactly on the segments that cross at p, so they are found
+	// by their position, along with any segments that overlap them
+	if len(e.crossing) > 0 {
+		for _, s := range e.crossing {
+			n, ok := sw.nodes[s]
+			if !ok {
+				continue
+			}
+			i := n.rank()
+			if l == r {
+				l, r = i, i+1
+			}
+			l, r = min(l, i), max(r, i+1)
+		}
+		for l > 0 && l < r && sw.collinear(sw.at(l-1), sw.at(l)) {
+			l--
+		}
+		for r < ln && l < r && sw.collinear(sw.at(r-1), sw.at(r)) {
+			r++
+		}
+	}
+
+	// take the segments that contain p out of the status, the ones that do
+	// not end at p continue through
+	left, rest := split(sw.status, l)
+	mid, right := split(rest, r-l)
+	var ins []int
+	var through []int
+	mid.each(func(i int) {
+		delete(sw.nodes, i)
+		through = append(through, i)
+		if sw.segs[i][1] != p {
+			ins = append(ins, i)
+		}
+	})
+
+	if n := len(e.upper) + len(e.points) + len(through); n > 1 {
+		idxs := make([]int, 0, n)
+		idxs = append(idxs, e.upper...)
+		idxs = append(idxs, e.points...)
+		idxs = append(idxs, through...)
+		sw.out = append(sw.out, SegmentIntersection{
+			Point:    p,
+			Segments: uniqueInts(idxs),
+		})
+	}
+
+	// reinsert the segments below p ordered by their direction
+	ins = append(ins, e.upper...)
+	sort.Slice(ins, func(i, j int) bool {
+		a, b := sw.segs[ins[i]], sw.segs[ins[j]]
+		if c := crossDiff(a[0], a[1], b[0], b[1]); c != 0 {
+			return c < 0
+		}
+		return ins[i] < ins[j]
+	})
+	var middle *statusNode
+	for _, i := range ins {
+		n := sw.newNode(i)
+		sw.nodes[i] = n
+		middle = merge(middle, n)
+	}
+
+	// the neighbors of p are at the ends of left and right
+	prev, next := left.last(), right.first()
+	sw.status = merge(merge(left, middle), right)
+	if sw.status != nil {
+		sw.status.parent = nil
+	}
+
+	if len(ins) == 0 {
+		if prev != nil && next != nil {
+			sw.check(prev.seg, next.seg, p)
+		}
+		return
+	}
+	if prev != nil {
+		sw.check(prev.seg, ins[0], p)
+	}
+	if next != nil {
+		sw.check(ins[len(ins)-1], next.seg, p)
+	}
+}
+
+func (sw *sweep) newNode(seg int) *statusNode {
+	// xorshift
+	sw.seed ^= sw.seed << 13
+	sw.seed ^= sw.seed >> 7
+	sw.seed ^= sw.seed << 17
+	return &statusNode{
+		seg:      seg,
+		priority: sw.seed,
+		size:     1,
+	}
+}
+
+// statusNode is a node in a treap that holds the status of the sweep. The
+// nodes are ordered by their position rather than a key, which allows the
+// order of the segments to be changed where they cross. Split and merge take
+// O(log n) expected time.
+type statusNode struct {
+	seg                 int
+	priority            uint64
+	size                int
+	left, right, parent *statusNode
+}
+
+func (n *statusNode) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update sets the size of n and the parent of its children.
+func (n *statusNode) update() {
+	n.size = 1 + n.left.len() + n.right.len()
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+}
+
+// split returns the first k nodes of n and the rest.
+func split(n *statusNode, k int) (*statusNode, *statusNode) {
+	if n == nil {
+		return nil, nil
+	}
+	n.parent = nil
+	if k <= n.left.len() {
+		l, r := split(n.left, k)
+		n.left = r
+		n.update()
+		return l, n
+	}
+	l, r := split(n.right, k-n.left.len()-1)
+	n.right = l
+	n.update()
+	return n, r
+}
+
+// merge returns the nodes of a followed by the nodes of b.
+func merge(a, b *statusNode) *statusNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = merge(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = merge(a, b.left)
+	b.update()
+	return b
+}
+
+// at returns the node at idx.
+func (n *statusNode) at(idx int) *statusNode {
+	for {
+		ln := n.left.len()
+		if idx == ln {
+			return n
+		}
+		if idx < ln {
+			n = n.left
+		} else {
+			idx -= ln + 1
+			n = n.right
+		}
+	}
+}
+
+// rank returns the index of n in its tree.
+func (n *statusNode) rank() int {
+	r := n.left.len()
+	for ; n.parent != nil; n = n.parent {
+		if n.parent.right == n {
+			r += n.parent.left.len() + 1
+		}
+	}
+	return r
+}
+
+// search returns the index of the first segment where fn returns true. Like
+// sort.Search, fn must be false and then true for the segments in order.
+func (n *statusNode) search(fn func(seg int) bool) int {
+	idx, found := 0, n.len()
+	for n != nil {
+		if fn(n.seg) {
+			found = idx + n.left.len()
+			n = n.left
+		} else {
+			idx += n.left.len() + 1
+			n = n.right
+		}
+	}
+	return found
+}
+
+func (n *statusNode) first() *statusNode {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *statusNode) last() *statusNode {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// each calls fn with the segment of each node in order.
+func (n *statusNode) each(fn func(seg int)) {
+	if n == nil {
+		return
+	}
+	n.left.each(fn)
+	fn(n.seg)
+	n.right.each(fn)
+}
+
+// check adds an event if segments a and b cross after p.
+func (sw *sweep) check(a, b int, p F) {
+	if a > b {
+		// keep the computed point the same regardless of the order
+		a, b = b, a
+	}
+	sa, sb := sw.segs[a], sw.segs[b]
+	if !segmentsTouch(sa[0], sa[1], sb[0], sb[1]) {
+		return
+	}
+	if crossDiff(sa[0], sa[1], sb[0], sb[1]) == 0 {
+		// collinear, the overlap is found at the ends of the segments
+		return
+	}
+	q, ok := crossPoint(sa, sb)
+	if !ok || !before(p, q) {
+		return
+	}
+	e := sw.event(q)
+	e.crossing = append(e.crossing, a, b)
+}
+
+// crossPoint returns the point where the lines through a and b cross, limited
+// to the bounding box of both segments so rounding cannot move it off either.
+// Both segments must be ordered with the first point before the second.
+func crossPoint(a, b Segment) (F, bool) {
+	x1, y1, x2, y2 := a[0].X, a[0].Y, a[1].X, a[1].Y
+	x3, y3, x4, y4 := b[0].X, b[0].Y, b[1].X, b[1].Y
+	d := (x1-x2)*(y3-y4) - (y1-y2)*(x3-x4)
+	if d == 0 {
+		return F{}, false
+	}
+	c1 := x1*y2 - y1*x2
+	c2 := x3*y4 - y3*x4
+	q := F{
+		X: (c1*(x3-x4) - (x1-x2)*c2) / d,
+		Y: (c1*(y3-y4) - (y1-y2)*c2) / d,
+	}
+	clamp := func(v, a0, a1, b0, b1 float64) float64 {
+		lo := math.Max(math.Min(a0, a1), math.Min(b0, b1))
+		hi := math.Min(math.Max(a0, a1), math.Max(b0, b1))
+		return math.Max(lo, math.Min(hi, v))
+	}
+	q.X = clamp(q.X, x1, x2, x3, x4)
+	q.Y = clamp(q.Y, y1, y2, y3, y4)
+	// the box allows points after the end of a segment in the order of the
+	// sweep, which would be handled after the segment is removed
+	for _, end := range []F{a[1], b[1]} {
+		if before(end, q) {
+			q = end
+		}
+	}
+	return q, true
+}
+
+// uniqueInts sorts the values and removes duplicates.
+func uniqueInts(s []int) []int {
+	sort.Ints(s)
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
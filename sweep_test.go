@@ -0,0 +1,154 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestIntersectionsSmall(t *testing.T) {
+	segs := []Segment{
+		{{0, 0}, {4, 4}},
+		{{0, 4}, {4, 0}},
+		{{2, -1}, {2, 5}},
+		{{5, 5}, {6, 6}},
+	}
+	got := Intersections(segs)
+	assert.Equal(t, []SegmentIntersection{
+		{Point: F{2, 2}, Segments: []int{0, 1, 2}},
+	}, got)
+
+	// horizontal and vertical segments, touching ends and overlaps
+	segs = []Segment{
+		{{0, 0}, {10, 0}},
+		{{5, -5}, {5, 5}},
+		{{10, 0}, {10, 3}},
+		{{5, 3}, {5, 8}},
+		{{2, 0}, {4, 0}},
+		{{7, 7}, {7, 7}},
+		{{5, 7}, {9, 7}},
+	}
+	got = Intersections(segs)
+	assert.Equal(t, []SegmentIntersection{
+		{Point: F{2, 0}, Segments: []int{0, 4}},
+		{Point: F{4, 0}, Segments: []int{0, 4}},
+		{Point: F{5, 0}, Segments: []int{0, 1}},
+		{Point: F{10, 0}, Segments: []int{0, 2}},
+		{Point: F{5, 3}, Segments: []int{1, 3}},
+		{Point: F{5, 5}, Segments: []int{1, 3}},
+		{Point: F{5, 7}, Segments: []int{3, 6}},
+		{Point: F{7, 7}, Segments: []int{5, 6}},
+	}, got)
+
+	assert.Nil(t, Intersections(nil))
+	sq := RectangleToPoints(F{0, 0}, F{1, 1})
+	assert.Len(t, Intersections(sq.Segments()), 4)
+	assert.Len(t, Intersections(LineSegments(sq).Segments()), 2)
+}
+
+// bruteIntersections returns each pair of segments that touch.
+func bruteIntersections(segs []Segment) map[[2]int]bool {
+	out := make(map[[2]int]bool)
+	for i, a := range segs {
+		for j := i + 1; j < len(segs); j++ {
+			b := segs[j]
+			if segmentsTouch(a[0], a[1], b[0], b[1]) {
+				out[[2]int{i, j}] = true
+			}
+		}
+	}
+	return out
+}
+
+func checkIntersections(t *testing.T, segs []Segment) {
+	expected := bruteIntersections(segs)
+	got := make(map[[2]int]bool)
+	var prev F
+	for i, x := range Intersections(segs) {
+		if i > 0 {
+			assert.False(t, before(x.Point, prev))
+		}
+		prev = x.Point
+		assert.True(t, len(x.Segments) > 1)
+		for j, a := range x.Segments {
+			s := segs[a]
+			assert.InDelta(t, 0, segmentDistance(x.Point, s[0], s[1]), 1e-9)
+			for _, b := range x.Segments[j+1:] {
+				got[[2]int{a, b}] = true
+			}
+		}
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestIntersectionsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(31415))
+	rf := func() F { return F{r.Float64() * 100, r.Float64() * 100} }
+	for n := 0; n < 20; n++ {
+		segs := make([]Segment, 100)
+		for i := range segs {
+			a := rf()
+			segs[i] = Segment{a, a.Add(rf().Subtract(F{50, 50}).ScalarMultiply(0.4))}
+		}
+		checkIntersections(t, segs)
+	}
+}
+
+func TestIntersectionsGrid(t *testing.T) {
+	// small integer coordinates produce many shared ends, vertical and
+	// horizontal segments and collinear overlaps
+	r := rand.New(rand.NewSource(2718))
+	ri := func() F { return F{float64(r.Intn(8)), float64(r.Intn(8))} }
+	for n := 0; n < 50; n++ {
+		segs := make([]Segment, 30)
+		for i := range segs {
+			segs[i] = Segment{ri(), ri()}
+		}
+		checkIntersections(t, segs)
+	}
+}
+
+func TestIntersectionsPolyline(t *testing.T) {
+	// a long, mostly horizontal polyline keeps most of its segments crossing
+	// the sweep line at once
+	r := rand.New(rand.NewSource(1414))
+	ls := make(LineSegments, 2000)
+	for i := range ls {
+		ls[i] = F{float64(i), r.Float64()}
+	}
+	checkIntersections(t, ls.Segments())
+}
+
+func TestIntersectionsRounding(t *testing.T) {
+	// the computed crossing of the first two segments is past the end of the
+	// second in the order of the sweep
+	checkIntersections(t, []Segment{
+		{{2.6723859313200795, 8.224758185802566}, {0.7188382963810599, 3.3818682930005335}},
+		{{9.299710318881711e-16, 3.2829040579855318}, {-0.8332892179406065, 7.928217315261054}},
+		{{-0.8332892179406065, 7.928217315261054}, {-0.24062178416870406, 1.132036490796511}},
+	})
+}
+
+func TestNonIntersectingRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1618))
+	for n := 0; n < 200; n++ {
+		p := make(Polygon, 3+r.Intn(6))
+		for i := range p {
+			p[i] = F{float64(r.Intn(6)), float64(r.Intn(6))}
+		}
+		expected := true
+		for i := range p {
+			for j := i + 2; j < len(p); j++ {
+				if i == 0 && j == len(p)-1 {
+					continue
+				}
+				a, b := p[i], p[(i+1)%len(p)]
+				c, d := p[j], p[(j+1)%len(p)]
+				if segmentsTouch(a, b, c, d) {
+					expected = false
+				}
+			}
+		}
+		assert.Equal(t, expected, p.NonIntersecting(), p)
+	}
+}
@@ -24,6 +24,14 @@ func (t Transformation) Apply(f F) F {
 	}
 }
 
+// Near returns true if each component of the transformations are within eps of
+// each other.
+func (t Transformation) Near(t2 Transformation, eps float64) bool {
+	return t.Translation.Near(t2.Translation, eps) &&
+		t.X.Near(t2.X, eps) &&
+		t.Y.Near(t2.Y, eps)
+}
+
 // Slice applies the transformation to a slice of vectors.
 func (t Transformation) Slice(fs []F) []F {
 	out := make([]F, len(fs))
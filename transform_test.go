@@ -119,3 +119,17 @@ func TestTriangleTransform(t *testing.T) {
 	}
 
 }
+
+func TestTransformationNear(t *testing.T) {
+	a := Triangle{{0, 0}, {1, 0}, {0, 1}}
+	b := Triangle{{1, 1}, {1, 3}, {-2, 1}}
+	tfrm, err := TriangleTransform(a, b)
+	assert.NoError(t, err)
+	expected := Transformation{
+		Translation: F{1, 1},
+		X:           F{0, 2},
+		Y:           F{-3, 0},
+	}
+	assert.True(t, expected.Near(tfrm, Epsilon))
+	assert.False(t, IdentityTransformation().Near(tfrm, Epsilon))
+}
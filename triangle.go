@@ -9,24 +9,31 @@ type Triangle [3]F
 
 // Contains returns true if point f is inside the triangle
 func (t Triangle) Contains(f F) bool {
-	// If a point is inside the triangle, the sign of the cross product from the
-	// point to each vertex will be the same. But a cross product of exactly 0
-	// doesn't have a sign.
-	s1 := t[0].Subtract(t[1])
-	r1 := f.Subtract(t[1])
-	s2 := t[1].Subtract(t[2])
-	r2 := f.Subtract(t[2])
-
-	c1 := s1.Cross(r1)
-	c2 := s2.Cross(r2)
-	if !(c1 >= 0 && c2 >= 0) && !(c1 <= 0 && c2 <= 0) {
-		return false
+	// If a point is inside the triangle, the orientation of the point relative
+	// to each side will be the same. An orientation of exactly 0 means the
+	// point is on that side.
+	o1 := sign(Orient(t[0], t[1], f))
+	o2 := sign(Orient(t[1], t[2], f))
+	o3 := sign(Orient(t[2], t[0], f))
+	if o1 == 0 && o2 == 0 && o3 == 0 {
+		// degenerate triangle, all points are collinear
+		return segmentsTouch(t[0], t[1], f, f) ||
+			segmentsTouch(t[1], t[2], f, f) ||
+			segmentsTouch(t[2], t[0], f, f)
 	}
+	return (o1 >= 0 && o2 >= 0 && o3 >= 0) || (o1 <= 0 && o2 <= 0 && o3 <= 0)
+}
+
+// Near returns true if each vertex is within eps of the vertex at the same index
+// in t2.
+func (t Triangle) Near(t2 Triangle, eps float64) bool {
+	return t[0].Near(t2[0], eps) && t[1].Near(t2[1], eps) && t[2].Near(t2[2], eps)
+}
 
-	s3 := t[2].Subtract(t[0])
-	r3 := f.Subtract(t[0])
-	c3 := s3.Cross(r3)
-	return (c2 >= 0 && c3 >= 0) || (c2 <= 0 && c3 <= 0)
+// Equivalent returns true if the triangles have the same vertexes within eps,
+// regardless of the starting vertex or the order.
+func (t Triangle) Equivalent(t2 Triangle, eps float64) bool {
+	return Polygon(t[:]).Equivalent(Polygon(t2[:]), eps)
 }
 
 // SignedArea of the triangle
@@ -58,3 +58,14 @@ func TestCircumscribedCircle(t *testing.T) {
 	assert.InDelta(t, c.Radius(), c.Centroid().Distance(p2), 1E-10)
 	assert.InDelta(t, c.Radius(), c.Centroid().Distance(p3), 1E-10)
 }
+
+func TestTriangleEquivalent(t *testing.T) {
+	tri := Triangle{{0, 0}, {1, 0}, {0, 1}}
+	tfrm := Transformation{X: F{0, 1}, Y: F{-1, 0}}
+	rt := Triangle{tfrm.Apply(tri[0]), tfrm.Apply(tri[1]), tfrm.Apply(tri[2])}
+	tfrm = Transformation{X: F{0, -1}, Y: F{1, 0}}
+	rt = Triangle{tfrm.Apply(rt[0]), tfrm.Apply(rt[1]), tfrm.Apply(rt[2])}
+	assert.True(t, tri.Near(rt, Epsilon))
+	assert.True(t, tri.Equivalent(Triangle{{0, 1}, {1, 0}, {0, 0}}, Epsilon))
+	assert.False(t, tri.Near(Triangle{{0, 1}, {1, 0}, {0, 0}}, Epsilon))
+}
@@ -0,0 +1,157 @@
+package vec2d
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Integer is the constraint for the integer element types a V can hold.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the constraint for the floating point element types a V can hold.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the constraint for any element type a V can hold.
+type Number interface {
+	Integer | Float
+}
+
+// V is a 2D vector with an arbitrary numeric element type. F and I can be
+// converted to and from V[float64] and V[int] without loss.
+type V[T Number] struct {
+	X, Y T
+}
+
+// Add returns v + v2
+func (v V[T]) Add(v2 V[T]) V[T] {
+	return V[T]{v.X + v2.X, v.Y + v2.Y}
+}
+
+// Subtract returns v - v2
+func (v V[T]) Subtract(v2 V[T]) V[T] {
+	return V[T]{v.X - v2.X, v.Y - v2.Y}
+}
+
+// Multiply returns V{v.X * v2.X, v.Y * v2.Y}
+func (v V[T]) Multiply(v2 V[T]) V[T] {
+	return V[T]{v.X * v2.X, v.Y * v2.Y}
+}
+
+// ScalarMultiply returns V{v.X*sclr, v.Y*sclr}
+func (v V[T]) ScalarMultiply(sclr T) V[T] {
+	return V[T]{v.X * sclr, v.Y * sclr}
+}
+
+// Area returns v.X*v.Y
+func (v V[T]) Area() T {
+	return v.X * v.Y
+}
+
+// Cross returns the cross product of the two vectors
+func (v V[T]) Cross(v2 V[T]) T {
+	return v.X*v2.Y - v2.X*v.Y
+}
+
+// Dot returns the dot product of two vectors
+func (v V[T]) Dot(v2 V[T]) T {
+	return v.X*v2.X + v.Y*v2.Y
+}
+
+// Mag2 returns the square of the magnitude of the vector.
+func (v V[T]) Mag2() T {
+	return v.X*v.X + v.Y*v.Y
+}
+
+// Mag returns the magnitude (distance to origin) of the vector. The
+// computation is done in float64 so that integer vectors do not overflow.
+func (v V[T]) Mag() float64 {
+	return v.F().Mag()
+}
+
+// Angle returns the angle in radians
+func (v V[T]) Angle() float64 {
+	return math.Atan2(float64(v.Y), float64(v.X))
+}
+
+// Distance returns the distance between to points
+func (v V[T]) Distance(v2 V[T]) float64 {
+	return v.F().Distance(v2.F())
+}
+
+// LineTo returns a line where t=0 returns the from point and t=1 returns the to
+// point. The line is always computed in float64.
+func (v V[T]) LineTo(to V[T]) Line {
+	return v.F().LineTo(to.F())
+}
+
+// F converts the vector to a float64 vector.
+func (v V[T]) F() F {
+	return F{float64(v.X), float64(v.Y)}
+}
+
+// I converts the vector to an int vector. Floating point values are truncated
+// the same way F.I truncates.
+func (v V[T]) I() I {
+	return I{int(v.X), int(v.Y)}
+}
+
+// String fulfills Stringer, returns the vector as "(X, Y)". Integer element
+// types are printed without a decimal and floating point types use Prec.
+func (v V[T]) String() string {
+	return strings.Join([]string{
+		"(",
+		formatNumber(v.X),
+		", ",
+		formatNumber(v.Y),
+		")",
+	}, "")
+}
+
+func formatNumber[T Number](n T) string {
+	if isFloat[T]() {
+		return strconv.FormatFloat(float64(n), 'f', Prec, 64)
+	}
+	if n < 0 {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+// isFloat reports if T is a floating point type, including named types.
+func isFloat[T Number]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// FromF converts an F to a V with any element type. Converting to V[float64]
+// is lossless.
+func FromF[T Number](f F) V[T] {
+	return V[T]{T(f.X), T(f.Y)}
+}
+
+// FromI converts an I to a V with any element type. Converting to V[int] is
+// lossless.
+func FromI[T Number](i I) V[T] {
+	return V[T]{T(i.X), T(i.Y)}
+}
+
+// Convert changes the element type of a vector.
+func Convert[To, From Number](v V[From]) V[To] {
+	return V[To]{To(v.X), To(v.Y)}
+}
+
+// V converts F to V[float64]
+func (f F) V() V[float64] {
+	return V[float64](f)
+}
+
+// V converts I to V[int]
+func (i I) V() V[int] {
+	return V[int](i)
+}
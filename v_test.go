@@ -0,0 +1,39 @@
+package vec2d
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVFloat32(t *testing.T) {
+	a := V[float32]{1, 2}
+	b := V[float32]{3, 5}
+	assert.Equal(t, V[float32]{4, 7}, a.Add(b))
+	assert.Equal(t, V[float32]{-2, -3}, a.Subtract(b))
+	assert.Equal(t, float32(13), a.Dot(b))
+	assert.Equal(t, float32(-1), a.Cross(b))
+	assert.Equal(t, 5.0, V[float32]{3, 4}.Mag())
+	assert.Equal(t, F{2, 3.5}, a.LineTo(b)(0.5))
+	assert.Equal(t, "(1.0000, 2.0000)", a.String())
+}
+
+func TestVInt64(t *testing.T) {
+	a := V[int64]{1 << 40, 2}
+	b := V[int64]{1, 1 << 40}
+	assert.Equal(t, V[int64]{1<<40 + 1, 1<<40 + 2}, a.Add(b))
+	assert.Equal(t, int64(3<<40), a.Dot(b))
+	assert.Equal(t, "(1099511627776, 2)", a.String())
+	assert.Equal(t, "(-1, 2)", V[int8]{-1, 2}.String())
+}
+
+func TestVConvert(t *testing.T) {
+	f := F{1.5, -2.25}
+	assert.Equal(t, f, f.V().F())
+	assert.Equal(t, f, FromF[float64](f).F())
+	assert.Equal(t, V[float32]{1.5, -2.25}, FromF[float32](f))
+
+	i := I{-3, 7}
+	assert.Equal(t, i, i.V().I())
+	assert.Equal(t, i, FromI[int64](i).I())
+	assert.Equal(t, V[float64]{-3, 7}, Convert[float64](i.V()))
+}